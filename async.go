@@ -0,0 +1,50 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+)
+
+// Result holds the outcome of a chain that has been executed asynchronously
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// GoProcess is the asynchronous equivalent of Process.  The chain is run in a separate
+// goroutine, with its outcome delivered as a single Result on the returned channel, which
+// is then closed.  The returned cancel func aborts the chain; a Result wrapping
+// ErrContextDone is always delivered so that callers are never left blocked.
+func GoProcess[T any](ctx context.Context, fs []Func, fn FinalFunc[T], args ...any) (<-chan Result[T], context.CancelFunc) {
+	return GoProcessWithRetries(ctx, fs, fn, Retry{}, args...)
+}
+
+// GoProcessWithRetries is the asynchronous equivalent of ProcessWithRetries
+func GoProcessWithRetries[T any](ctx context.Context, fs []Func, fn FinalFunc[T], retry Retry, args ...any) (<-chan Result[T], context.CancelFunc) {
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	out := make(chan Result[T], 1)
+
+	go func() {
+		defer close(out)
+		defer cancel()
+
+		// Buffered so that a func that ignores cancellation does not leak this goroutine
+		// once it eventually completes
+		done := make(chan Result[T], 1)
+		go func() {
+			v, err := ProcessWithRetries(ctx, fs, fn, retry, args...)
+			done <- Result[T]{Value: v, Err: err}
+		}()
+
+		select {
+		case r := <-done:
+			out <- r
+		case <-ctx.Done():
+			out <- Result[T]{Err: fmt.Errorf("async chain cancelled, %w: %w", ErrContextDone, context.Cause(ctx))}
+		}
+	}()
+
+	return out, cancel
+}
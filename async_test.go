@@ -0,0 +1,62 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func ExampleGoProcess() {
+	f := func(ctx context.Context, args ...any) ([]any, error) {
+		x := args[0].(int)
+		return []any{x + 1}, nil
+	}
+
+	finally := func(ctx context.Context, args ...any) (int, error) {
+		x := args[0].(int)
+		return x * x, nil
+	}
+
+	ch, cancel := GoProcess(context.Background(), []Func{f}, finally, 5)
+	defer cancel()
+
+	r := <-ch
+
+	fmt.Println("Result:", r.Value)
+	// Output: Result: 36
+}
+
+func TestGoProcess(t *testing.T) {
+
+	// Deliberately ignores the context, so that cancellation must not depend on the func
+	blocked := func(ctx context.Context, args ...any) ([]any, error) {
+		<-time.After(time.Second)
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	ch, cancel := GoProcess(context.Background(), []Func{blocked}, f, 5)
+
+	go func() {
+		<-time.After(5 * time.Millisecond)
+		cancel()
+	}()
+
+	select {
+	case r := <-ch:
+		if !errors.Is(r.Err, ErrContextDone) {
+			t.Fatalf("expected context done error, got: %v", r.Err)
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("cancellation was not prompt")
+	}
+
+	if _, ok := <-ch; ok {
+		t.Fatal("expected channel to be closed after the result")
+	}
+}
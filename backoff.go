@@ -0,0 +1,80 @@
+package chain
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Backoff determines the wait following a failed attempt, given the zero-based index of
+// the attempt and the BaseWait of the retry policy.  The wait returned is capped at the
+// policy's MaxWait.
+type Backoff interface {
+	NextWait(attempt int, base time.Duration) time.Duration
+}
+
+// maxBackoff bounds the doubling of backoffs, so that they cannot overflow
+const maxBackoff = 5 * time.Minute
+
+// int63n returns a random value in [0, n) from r, or the global source if r is nil
+func int63n(r *rand.Rand, n int64) int64 {
+	if n <= 0 {
+		return 0
+	}
+	if r != nil {
+		return r.Int63n(n)
+	}
+	return rand.Int63n(n)
+}
+
+// ConstantBackoff waits for the base duration after every attempt
+type ConstantBackoff struct{}
+
+// NextWait returns base
+func (ConstantBackoff) NextWait(attempt int, base time.Duration) time.Duration {
+	return base
+}
+
+// ExponentialBackoff waits for base * 2^attempt, plus up to 50% jitter
+type ExponentialBackoff struct {
+	// Rand, if not nil, is the source of jitter.  Default = the global math/rand source
+	Rand *rand.Rand
+}
+
+// NextWait returns the exponential backoff with jitter
+func (b ExponentialBackoff) NextWait(attempt int, base time.Duration) time.Duration {
+	backoff := max(base, 0)
+	for i := 0; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, maxBackoff)
+
+	return backoff + time.Duration(int63n(b.Rand, int64(backoff/2)))
+}
+
+// DecorrelatedJitterBackoff waits for a random duration between base and three times the
+// previous wait, which spreads out retries from many clients more evenly than exponential
+// backoff.  The previous wait is reset by attempt 0; as this state is held by the
+// DecorrelatedJitterBackoff, it should not be shared by chains executing concurrently.
+type DecorrelatedJitterBackoff struct {
+	// Rand, if not nil, is the source of randomness.  Default = the global math/rand source
+	Rand *rand.Rand
+
+	lck  sync.Mutex
+	prev time.Duration
+}
+
+// NextWait returns a random duration in [base, 3 * previous wait)
+func (b *DecorrelatedJitterBackoff) NextWait(attempt int, base time.Duration) time.Duration {
+	b.lck.Lock()
+	defer b.lck.Unlock()
+
+	base = max(base, 0)
+	if attempt == 0 || b.prev < base {
+		b.prev = base
+	}
+
+	upper := min(3*b.prev, maxBackoff)
+	b.prev = base + time.Duration(int63n(b.Rand, int64(upper-base)))
+	return b.prev
+}
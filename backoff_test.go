@@ -0,0 +1,97 @@
+package chain
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func waits(b Backoff, base time.Duration, n int) []time.Duration {
+	var out []time.Duration
+	for attempt := range n {
+		out = append(out, b.NextWait(attempt, base))
+	}
+	return out
+}
+
+func TestConstantBackoff(t *testing.T) {
+
+	got := waits(ConstantBackoff{}, 10*time.Millisecond, 3)
+	want := []time.Duration{10 * time.Millisecond, 10 * time.Millisecond, 10 * time.Millisecond}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("unexpected waits.  wanted: %v, got: %v", want, got)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+
+	base := 10 * time.Millisecond
+
+	got := waits(ExponentialBackoff{Rand: rand.New(rand.NewSource(1))}, base, 4)
+
+	// Replay the same source to derive the expected jitter
+	src := rand.New(rand.NewSource(1))
+	var want []time.Duration
+	for attempt := range 4 {
+		backoff := base << attempt
+		want = append(want, backoff+time.Duration(src.Int63n(int64(backoff/2))))
+	}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("unexpected waits.  wanted: %v, got: %v", want, got)
+	}
+	for attempt, w := range got {
+		if backoff := base << attempt; w < backoff || w >= backoff*3/2 {
+			t.Fatalf("attempt %d: wait %v outside of [%v, %v)", attempt, w, backoff, backoff*3/2)
+		}
+	}
+
+	again := waits(ExponentialBackoff{Rand: rand.New(rand.NewSource(1))}, base, 4)
+	if !slices.Equal(got, again) {
+		t.Fatalf("expected seeded source to be reproducible, got: %v and %v", got, again)
+	}
+}
+
+func TestDecorrelatedJitterBackoff(t *testing.T) {
+
+	base := 10 * time.Millisecond
+
+	got := waits(&DecorrelatedJitterBackoff{Rand: rand.New(rand.NewSource(1))}, base, 4)
+
+	src := rand.New(rand.NewSource(1))
+	var want []time.Duration
+	prev := base
+	for range 4 {
+		prev = base + time.Duration(src.Int63n(int64(3*prev-base)))
+		want = append(want, prev)
+	}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("unexpected waits.  wanted: %v, got: %v", want, got)
+	}
+
+	// Attempt 0 resets the sequence
+	b := &DecorrelatedJitterBackoff{Rand: rand.New(rand.NewSource(1))}
+	waits(b, base, 4)
+	b.Rand = rand.New(rand.NewSource(1))
+	if again := waits(b, base, 4); !slices.Equal(got, again) {
+		t.Fatalf("expected sequence to restart, got: %v and %v", got, again)
+	}
+}
+
+func TestRetry_Strategy(t *testing.T) {
+
+	r := Retry{BaseWait: time.Second, MaxWait: 2 * time.Second, Strategy: ConstantBackoff{}}.ensureValid()
+	if w := r.wait(5); w != time.Second {
+		t.Fatalf("expected strategy to be used, got: %v", w)
+	}
+
+	r = Retry{BaseWait: time.Second, MaxWait: 2 * time.Second, Strategy: &DecorrelatedJitterBackoff{}}.ensureValid()
+	for attempt := range 8 {
+		if w := r.wait(attempt); w > r.MaxWait {
+			t.Fatalf("expected strategy to be capped at MaxWait, got: %v", w)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ProcessParallel runs the chain of fs and fn over each set of inputs, with at most
+// concurrency chains executing at once.  Results and errors are returned positionally, so
+// that the result and error of inputs[i] are at index i.  Once the context is done no
+// further chains are started, and the inputs not yet started fail with ErrContextDone.
+// A concurrency <= 0 runs all chains at once.
+func ProcessParallel[T any](ctx context.Context, fs []Func, fn FinalFunc[T], inputs [][]any, concurrency int) ([]T, []error) {
+	results := make([]T, len(inputs))
+	errs := make([]error, len(inputs))
+
+	if concurrency <= 0 {
+		concurrency = max(len(inputs), 1)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for i, args := range inputs {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+
+		// Checked after acquiring, as select does not prefer the context being done
+		if ctx.Err() != nil {
+			for j := i; j < len(inputs); j++ {
+				errs[j] = fmt.Errorf("prior to processing input %d, %w: %w", j, ErrContextDone, context.Cause(ctx))
+			}
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errs[i] = Process(ctx, fs, fn, args...)
+		}()
+	}
+
+	wg.Wait()
+
+	return results, errs
+}
@@ -0,0 +1,112 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestProcessParallel(t *testing.T) {
+
+	var running, peak atomic.Int32
+
+	square := func(ctx context.Context, args ...any) ([]any, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		x := args[0].(int)
+		// Later inputs complete first, so ordering must not depend upon completion
+		<-time.After(time.Duration(10-x) * time.Millisecond)
+		return []any{x * x}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	var inputs [][]any
+	for i := range 10 {
+		inputs = append(inputs, []any{i})
+	}
+
+	for _, concurrency := range []int{1, 4} {
+		peak.Store(0)
+
+		results, errs := ProcessParallel(context.Background(), []Func{square}, f, inputs, concurrency)
+
+		for i := range inputs {
+			if errs[i] != nil {
+				t.Fatalf("input %d: unexpected error, got: %v", i, errs[i])
+			}
+			if results[i] != i*i {
+				t.Fatalf("input %d: unexpected result, got: %v", i, results[i])
+			}
+		}
+		if p := peak.Load(); p > int32(concurrency) || (concurrency > 1 && p < 2) {
+			t.Fatalf("concurrency %d: unexpected peak concurrency, got: %d", concurrency, p)
+		}
+	}
+}
+
+func TestProcessParallel_1(t *testing.T) {
+
+	errOdd := errors.New("odd")
+
+	even := func(ctx context.Context, args ...any) ([]any, error) {
+		if args[0].(int)%2 != 0 {
+			return nil, errOdd
+		}
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	results, errs := ProcessParallel(context.Background(), []Func{even}, f, [][]any{{0}, {1}, {2}, {3}}, 0)
+
+	for i := range 4 {
+		if i%2 == 0 && (errs[i] != nil || results[i] != i) {
+			t.Fatalf("input %d: unexpected result, got: %v, %v", i, results[i], errs[i])
+		}
+		if i%2 != 0 && !errors.Is(errs[i], errOdd) {
+			t.Fatalf("input %d: expected underlying error, got: %v", i, errs[i])
+		}
+	}
+}
+
+func TestProcessParallel_2(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var started atomic.Int32
+	block := func(ctx context.Context, args ...any) ([]any, error) {
+		if started.Add(1) == 1 {
+			cancel()
+		}
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, errs := ProcessParallel(ctx, []Func{block}, f, [][]any{{0}, {1}, {2}, {3}}, 1)
+
+	if n := started.Load(); n != 1 {
+		t.Fatalf("expected dispatch to stop after cancellation, got: %d started", n)
+	}
+	for i := 1; i < 4; i++ {
+		if !errors.Is(errs[i], ErrContextDone) {
+			t.Fatalf("input %d: expected context done error, got: %v", i, errs[i])
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package chain
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Branch adds the funcs of either ifTrue or ifFalse as steps, depending upon whether pred
+// holds for the current args.  The funcs of the branch taken are added as if by Then, so
+// are subject to the chain's retry policy and context; the other branch is never invoked.
+// As the branch is only known at runtime, the step cannot be checkpointed; see
+// NewWithCheckpointer.
+func (c Chain[T]) Branch(pred func(args ...any) bool, ifTrue, ifFalse []Func) Chain[T] {
+	return c.queue(pred, cmp.Or(check(ErrNilThenFunc, pred), check(ErrNilThenFunc, ifTrue...), check(ErrNilThenFunc, ifFalse...)), func(c Chain[T]) Chain[T] {
+		if c.err != nil {
+			return c
+		}
+		if pred == nil {
+			return c.fail(ErrNilThenFunc)
+		}
+
+		test := func(ctx context.Context, args ...any) (bool, error) {
+			return pred(args...), nil
+		}
+
+		name := c.cfg.funcName(pred)
+		take, _, err := call(c.ctx, name, Retry{}, c.cfg.PanicMapper, c.cfg.PanicMode, test, slices.Clone(c.args))
+		if err != nil {
+			return c.fail(fmt.Errorf("error in %s: %w", name, err))
+		}
+
+		fs := ifFalse
+		if take {
+			fs = ifTrue
+		}
+
+		next := c
+		for _, f := range fs {
+			next = next.Then(f)
+		}
+		return next
+	}).uncheckpointable(selectsSteps)
+}
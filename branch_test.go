@@ -0,0 +1,83 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestChain_Branch(t *testing.T) {
+
+	var invoked []string
+	step := func(name string, delta int) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			invoked = append(invoked, name)
+			return []any{args[0].(int) + delta}, nil
+		}
+	}
+
+	large := func(args ...any) bool {
+		return args[0].(int) > 10
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	tests := []struct {
+		input   int
+		want    int
+		invoked string
+	}{
+		{20, 18, "[true1 true2]"},
+		{5, 105, "[false1]"},
+	}
+
+	for _, test := range tests {
+		invoked = nil
+
+		result, err := New[int](context.Background(), test.input).
+			Branch(large,
+				[]Func{step("true1", -1), step("true2", -1)},
+				[]Func{step("false1", 100)}).
+			Finally(f)
+
+		if err != nil {
+			t.Fatalf("unexpected error, got: %v", err)
+		}
+		if result != test.want {
+			t.Fatalf("unexpected result.  wanted: %d, got: %d", test.want, result)
+		}
+		if fmt.Sprint(invoked) != test.invoked {
+			t.Fatalf("unexpected funcs invoked.  wanted: %s, got: %v", test.invoked, invoked)
+		}
+	}
+}
+
+func TestChain_Branch_1(t *testing.T) {
+
+	boom := func(args ...any) bool {
+		panic("Branch Boom!")
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := New[int](context.Background()).
+		Branch(boom, nil, nil).
+		Finally(f)
+
+	if !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected caught panic error, got: %v", err)
+	}
+
+	_, err = New[int](context.Background()).
+		Branch(nil, nil, nil).
+		Finally(f)
+
+	if !errors.Is(err, ErrNilThenFunc) {
+		t.Fatalf("expected nil func error, got: %v", err)
+	}
+}
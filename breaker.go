@@ -0,0 +1,153 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is raised by a CircuitBreaker that is failing calls fast, without
+// executing their chains
+var ErrCircuitOpen = errors.New("circuit is open")
+
+// CircuitState is the state of a CircuitBreaker
+type CircuitState int
+
+const (
+	// CircuitClosed allows all calls, counting consecutive failures
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails all calls with ErrCircuitOpen until the cooldown has elapsed
+	CircuitOpen
+	// CircuitHalfOpen allows a single probe call, whose outcome closes or reopens the circuit
+	CircuitHalfOpen
+)
+
+// String returns the name of the state
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitBreaker protects a downstream dependency across repeated chain executions.  After
+// threshold consecutive failures the circuit opens, and calls fail immediately with
+// ErrCircuitOpen for the cooldown period.  The circuit then half-opens, allowing a single
+// call through as a probe: should it succeed the circuit closes, otherwise it reopens for
+// a further cooldown.  Calls arriving whilst the probe is in flight fail with ErrCircuitOpen,
+// and the outcomes of calls allowed before the circuit opened are disregarded once it has.
+// Calls that fail because the caller's context is done do not count as failures, and a
+// probe that does so allows a further probe.  A CircuitBreaker is safe for concurrent use,
+// so should be shared by all calls to the dependency it protects.
+type CircuitBreaker[T any] struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	lck      sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker that opens after threshold consecutive
+// failures, for cooldown.  A threshold < 1 is treated as 1.
+func NewCircuitBreaker[T any](threshold int, cooldown time.Duration) *CircuitBreaker[T] {
+	return &CircuitBreaker[T]{threshold: max(threshold, 1), cooldown: cooldown, now: time.Now}
+}
+
+// State returns the current state of the circuit
+func (cb *CircuitBreaker[T]) State() CircuitState {
+	cb.lck.Lock()
+	defer cb.lck.Unlock()
+
+	if cb.state == CircuitOpen && !cb.now().Before(cb.openedAt.Add(cb.cooldown)) {
+		return CircuitHalfOpen
+	}
+	return cb.state
+}
+
+// Process is the equivalent of Process, subject to the circuit
+func (cb *CircuitBreaker[T]) Process(ctx context.Context, fs []Func, fn FinalFunc[T], args ...any) (T, error) {
+	return cb.ProcessWithConfig(ctx, fs, fn, Config{}, args...)
+}
+
+// ProcessWithRetries is the equivalent of ProcessWithRetries, subject to the circuit.  Only
+// the outcome of the chain counts towards the circuit, not the individual attempts.
+func (cb *CircuitBreaker[T]) ProcessWithRetries(ctx context.Context, fs []Func, fn FinalFunc[T], retry Retry, args ...any) (T, error) {
+	return cb.ProcessWithConfig(ctx, fs, fn, Config{Retry: retry}, args...)
+}
+
+// ProcessWithConfig is the equivalent of ProcessWithConfig, subject to the circuit
+func (cb *CircuitBreaker[T]) ProcessWithConfig(ctx context.Context, fs []Func, fn FinalFunc[T], cfg Config, args ...any) (T, error) {
+	probe, err := cb.allow()
+	if err != nil {
+		var t T
+		return t, err
+	}
+
+	t, err := ProcessWithConfig(ctx, fs, fn, cfg, args...)
+	cb.record(probe, err != nil && ctx.Err() != nil, err)
+	return t, err
+}
+
+// allow returns ErrCircuitOpen if the call should fail fast, and otherwise whether the call
+// is the probe of a half-open circuit
+func (cb *CircuitBreaker[T]) allow() (bool, error) {
+	cb.lck.Lock()
+	defer cb.lck.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		if cb.now().Before(cb.openedAt.Add(cb.cooldown)) {
+			return false, ErrCircuitOpen
+		}
+		cb.state = CircuitHalfOpen
+		cb.probing = true
+		return true, nil
+	case CircuitHalfOpen:
+		if cb.probing {
+			return false, ErrCircuitOpen
+		}
+		cb.probing = true
+		return true, nil
+	}
+	return false, nil
+}
+
+// record updates the circuit with the outcome of a call that was allowed.  Only the probe
+// can change the state of a circuit that is not closed, and calls abandoned by their
+// caller are not counted.
+func (cb *CircuitBreaker[T]) record(probe, abandoned bool, err error) {
+	cb.lck.Lock()
+	defer cb.lck.Unlock()
+
+	if probe {
+		cb.probing = false
+	} else if cb.state != CircuitClosed {
+		return
+	}
+
+	if abandoned {
+		return
+	}
+
+	if err == nil {
+		cb.state = CircuitClosed
+		cb.failures = 0
+		return
+	}
+
+	cb.failures++
+	if probe || cb.failures >= cb.threshold {
+		cb.state = CircuitOpen
+		cb.openedAt = cb.now()
+	}
+}
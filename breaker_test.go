@@ -0,0 +1,267 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func ExampleCircuitBreaker() {
+
+	down := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errors.New("unavailable")
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	cb := NewCircuitBreaker[int](2, time.Minute)
+
+	for range 3 {
+		_, err := cb.Process(context.Background(), []Func{down}, f)
+		fmt.Println(errors.Is(err, ErrCircuitOpen), cb.State())
+	}
+	// Output:
+	// false closed
+	// false open
+	// true open
+}
+
+func TestCircuitBreaker(t *testing.T) {
+
+	now := time.Unix(0, 0)
+
+	cb := NewCircuitBreaker[int](3, time.Second)
+	cb.now = func() time.Time { return now }
+
+	var calls int
+	healthy := false
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		if !healthy {
+			return nil, errors.New("unavailable")
+		}
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 1, nil
+	}
+
+	process := func() error {
+		_, err := cb.Process(context.Background(), []Func{step}, f)
+		return err
+	}
+
+	// Closed: failures are counted until the threshold is reached
+	for i := range 3 {
+		if err := process(); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d: expected step error, got: %v", i, err)
+		}
+	}
+	if cb.State() != CircuitOpen {
+		t.Fatalf("expected open circuit, got: %v", cb.State())
+	}
+
+	// Open: calls fail fast without executing the chain
+	if err := process(); !errors.Is(err, ErrCircuitOpen) || calls != 3 {
+		t.Fatalf("expected fast failure, got: %v, %d", err, calls)
+	}
+
+	// Half-open once the cooldown has elapsed; a failed probe reopens the circuit
+	now = now.Add(time.Second)
+	if cb.State() != CircuitHalfOpen {
+		t.Fatalf("expected half-open circuit, got: %v", cb.State())
+	}
+	if err := process(); err == nil || errors.Is(err, ErrCircuitOpen) || calls != 4 {
+		t.Fatalf("expected probe to execute and fail, got: %v, %d", err, calls)
+	}
+	if err := process(); !errors.Is(err, ErrCircuitOpen) || calls != 4 {
+		t.Fatalf("expected circuit to reopen, got: %v, %d", err, calls)
+	}
+
+	// A successful probe closes the circuit
+	now = now.Add(time.Second)
+	healthy = true
+	if err := process(); err != nil {
+		t.Fatalf("expected probe to succeed, got: %v", err)
+	}
+	if cb.State() != CircuitClosed {
+		t.Fatalf("expected closed circuit, got: %v", cb.State())
+	}
+
+	// Failure count restarts once closed
+	healthy = false
+	_ = process()
+	_ = process()
+	if cb.State() != CircuitClosed {
+		t.Fatalf("expected circuit to remain closed, got: %v", cb.State())
+	}
+}
+
+func TestCircuitBreaker_1(t *testing.T) {
+
+	now := time.Unix(0, 0)
+
+	cb := NewCircuitBreaker[int](1, time.Second)
+	cb.now = func() time.Time { return now }
+
+	errFailed := errors.New("failed")
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errFailed
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 1, nil
+	}
+
+	if _, err := cb.Process(context.Background(), []Func{fail}, f); !errors.Is(err, errFailed) {
+		t.Fatalf("expected step error, got: %v", err)
+	}
+
+	// Only a single probe is allowed whilst half-open
+	now = now.Add(time.Second)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	wait := func(ctx context.Context, args ...any) ([]any, error) {
+		close(started)
+		<-release
+		return args, nil
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := cb.Process(context.Background(), []Func{wait}, f)
+		done <- err
+	}()
+
+	<-started
+	if _, err := cb.Process(context.Background(), []Func{wait}, f); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected fast failure during probe, got: %v", err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("expected probe to succeed, got: %v", err)
+	}
+	if cb.State() != CircuitClosed {
+		t.Fatalf("expected closed circuit, got: %v", cb.State())
+	}
+}
+
+func TestCircuitBreaker_2(t *testing.T) {
+
+	now := time.Unix(0, 0)
+
+	cb := NewCircuitBreaker[int](1, time.Second)
+	cb.now = func() time.Time { return now }
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 1, nil
+	}
+
+	// A slow call is allowed whilst the circuit is closed
+	releaseStale := make(chan struct{})
+	staleStarted := make(chan struct{})
+	stale := func(ctx context.Context, args ...any) ([]any, error) {
+		close(staleStarted)
+		<-releaseStale
+		return args, nil
+	}
+
+	staleDone := make(chan error)
+	go func() {
+		_, err := cb.Process(context.Background(), []Func{stale}, f)
+		staleDone <- err
+	}()
+	<-staleStarted
+
+	// The circuit then opens, and half-opens with a probe in flight
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errors.New("unavailable")
+	}
+	_, _ = cb.Process(context.Background(), []Func{fail}, f)
+	now = now.Add(time.Second)
+
+	releaseProbe := make(chan struct{})
+	probeStarted := make(chan struct{})
+	probe := func(ctx context.Context, args ...any) ([]any, error) {
+		close(probeStarted)
+		<-releaseProbe
+		return nil, errors.New("still unavailable")
+	}
+
+	probeDone := make(chan error)
+	go func() {
+		_, err := cb.Process(context.Background(), []Func{probe}, f)
+		probeDone <- err
+	}()
+	<-probeStarted
+
+	// The stale call completing must neither close the circuit nor allow a second probe
+	close(releaseStale)
+	if err := <-staleDone; err != nil {
+		t.Fatalf("unexpected error from stale call, got: %v", err)
+	}
+	if cb.State() != CircuitHalfOpen {
+		t.Fatalf("expected stale call not to change the circuit, got: %v", cb.State())
+	}
+	if _, err := cb.Process(context.Background(), nil, f); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected fast failure whilst probe in flight, got: %v", err)
+	}
+
+	close(releaseProbe)
+	if err := <-probeDone; err == nil {
+		t.Fatal("expected probe to fail")
+	}
+	if cb.State() != CircuitOpen {
+		t.Fatalf("expected failed probe to reopen the circuit, got: %v", cb.State())
+	}
+}
+
+func TestCircuitBreaker_3(t *testing.T) {
+
+	now := time.Unix(0, 0)
+
+	cb := NewCircuitBreaker[int](1, time.Second)
+	cb.now = func() time.Time { return now }
+
+	wait := func(ctx context.Context, args ...any) ([]any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 1, nil
+	}
+
+	cancelled := func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+		defer cancel()
+		_, err := cb.Process(ctx, []Func{wait}, f)
+		return err
+	}
+
+	// Calls abandoned by their caller do not count as failures
+	if err := cancelled(); err == nil || cb.State() != CircuitClosed {
+		t.Fatalf("expected circuit to remain closed, got: %v, %v", err, cb.State())
+	}
+
+	// Nor does an abandoned probe, which allows a further probe
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errors.New("unavailable")
+	}
+	_, _ = cb.Process(context.Background(), []Func{fail}, f)
+	now = now.Add(time.Second)
+
+	if err := cancelled(); errors.Is(err, ErrCircuitOpen) || cb.State() != CircuitHalfOpen {
+		t.Fatalf("expected abandoned probe to leave the circuit half-open, got: %v, %v", err, cb.State())
+	}
+	if _, err := cb.Process(context.Background(), nil, f); err != nil || cb.State() != CircuitClosed {
+		t.Fatalf("expected further probe to close the circuit, got: %v, %v", err, cb.State())
+	}
+}
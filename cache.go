@@ -0,0 +1,65 @@
+package chain
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// Store is the external store used by ThenCacheAside
+type Store interface {
+	// Get returns the args held for key, with false if key is not present
+	Get(ctx context.Context, key string) ([]any, bool, error)
+	// Set holds args against key
+	Set(ctx context.Context, key string, args []any) error
+}
+
+// ErrNilStore is raised if a nil Store or key func is passed to ThenCacheAside
+var ErrNilStore = errors.New("store and key func provided to ThenCacheAside cannot be nil")
+
+// ThenCacheAside adds a transformation step using the cache-aside pattern.  The store is
+// checked for the key derived from the current args, with the cached args being forwarded
+// on a hit.  On a miss, compute is invoked and its output written to the store before being
+// forwarded.  Store failures do not fail the step: a read error falls through to compute,
+// and a write error is ignored, with both logged as warnings to Config.Logger.
+func (c Chain[T]) ThenCacheAside(key func(...any) string, store Store, compute Func) Chain[T] {
+	return c.queue(compute, cmp.Or(check(ErrNilThenFunc, compute), check(ErrNilStore, key), check[Store](ErrNilStore, store)), func(c Chain[T]) Chain[T] {
+		if c.err == nil && compute != nil && (key == nil || store == nil) {
+			return c.fail(ErrNilStore)
+		}
+
+		var g Func
+		if compute != nil {
+			g = func(ctx context.Context, args ...any) ([]any, error) {
+				k := key(args...)
+
+				cached, ok, err := store.Get(ctx, k)
+				if err != nil {
+					c.cfg.warn(ctx, "cache read failed", slog.String("key", k), slog.Any("error", err))
+				} else if ok {
+					return cached, nil
+				}
+
+				result, err := compute(ctx, args...)
+				if err != nil {
+					return nil, err
+				}
+
+				if err := store.Set(ctx, k, result); err != nil {
+					c.cfg.warn(ctx, "cache write failed", slog.String("key", k), slog.Any("error", err))
+				}
+				return result, nil
+			}
+		}
+
+		return c.then(g, compute)
+	})
+}
+
+// warn logs to the configured logger, if any
+func (cfg Config) warn(ctx context.Context, msg string, attrs ...slog.Attr) {
+	if cfg.Logger != nil {
+		cfg.Logger.LogAttrs(ctx, slog.LevelWarn, msg, attrs...)
+	}
+}
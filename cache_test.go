@@ -0,0 +1,117 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type testStore struct {
+	data   map[string][]any
+	getErr error
+	setErr error
+}
+
+func (s *testStore) Get(ctx context.Context, key string) ([]any, bool, error) {
+	if s.getErr != nil {
+		return nil, false, s.getErr
+	}
+	v, ok := s.data[key]
+	return v, ok, nil
+}
+
+func (s *testStore) Set(ctx context.Context, key string, args []any) error {
+	if s.setErr != nil {
+		return s.setErr
+	}
+	s.data[key] = args
+	return nil
+}
+
+func TestChain_ThenCacheAside(t *testing.T) {
+
+	store := &testStore{data: map[string][]any{}}
+
+	key := func(args ...any) string {
+		return fmt.Sprint(args...)
+	}
+
+	computed := 0
+	square := func(ctx context.Context, args ...any) ([]any, error) {
+		computed++
+		x := args[0].(int)
+		return []any{x * x}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	for _, input := range []int{3, 3, 4} {
+		result, err := New[int](context.Background(), input).
+			ThenCacheAside(key, store, square).
+			Finally(f)
+
+		if err != nil {
+			t.Fatalf("unexpected error, got: %v", err)
+		}
+		if result != input*input {
+			t.Fatalf("unexpected result.  wanted: %d, got: %d", input*input, result)
+		}
+	}
+
+	if computed != 2 {
+		t.Fatalf("expected cache hit to skip compute, got computations: %d", computed)
+	}
+}
+
+func TestChain_ThenCacheAside_1(t *testing.T) {
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	store := &testStore{
+		getErr: errors.New("read unavailable"),
+		setErr: errors.New("write unavailable"),
+	}
+
+	key := func(args ...any) string {
+		return "k"
+	}
+
+	double := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0].(int) * 2}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	result, err := NewWithConfig[int](context.Background(), Config{Logger: logger}, 5).
+		ThenCacheAside(key, store, double).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("expected store errors to be absorbed, got: %v", err)
+	}
+	if result != 10 {
+		t.Fatalf("unexpected result.  wanted: 10, got: %d", result)
+	}
+
+	logged := buf.String()
+	if !strings.Contains(logged, "read unavailable") || !strings.Contains(logged, "write unavailable") {
+		t.Fatalf("expected store errors to be logged, got: %s", logged)
+	}
+
+	_, err = New[int](context.Background(), 5).
+		ThenCacheAside(key, nil, double).
+		Finally(f)
+
+	if !errors.Is(err, ErrNilStore) {
+		t.Fatalf("expected nil store error, got: %v", err)
+	}
+}
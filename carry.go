@@ -0,0 +1,41 @@
+package chain
+
+import (
+	"context"
+	"maps"
+)
+
+type carriedKey struct{}
+
+// Carry associates value with key for the remainder of the chain, independently of the
+// args, so that it reaches later steps and the final func via CarriedValues regardless of
+// how the args are reshaped in the meantime.  Carrying a key again replaces its value.
+func (c Chain[T]) Carry(key string, value any) Chain[T] {
+	return c.queue("carry", nil, func(c Chain[T]) Chain[T] {
+		if c.err != nil {
+			return c
+		}
+
+		existing, _ := c.ctx.Value(carriedKey{}).(map[string]any)
+		carried := maps.Clone(existing)
+		if carried == nil {
+			carried = map[string]any{}
+		}
+		carried[key] = value
+
+		next := c
+		next.ctx = context.WithValue(c.ctx, carriedKey{}, carried)
+		return next
+	}).nonStep()
+}
+
+// CarriedValues returns a copy of the values carried by the chain via Carry, keyed as
+// provided, which is empty if there are none
+func CarriedValues(ctx context.Context) map[string]any {
+	carried, _ := ctx.Value(carriedKey{}).(map[string]any)
+	out := maps.Clone(carried)
+	if out == nil {
+		out = map[string]any{}
+	}
+	return out
+}
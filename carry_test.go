@@ -0,0 +1,73 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func ExampleChain_Carry() {
+
+	replace := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{len(args)}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		return fmt.Sprintf("%v for %v", args[0], CarriedValues(ctx)["user"]), nil
+	}
+
+	s, _ := New[string](context.Background(), "a", "b").
+		Carry("user", "alice").
+		Then(replace).
+		Finally(f)
+
+	fmt.Println(s)
+	// Output: 2 for alice
+}
+
+func TestChain_Carry(t *testing.T) {
+
+	produce := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{"order-1", 42}, nil
+	}
+
+	replace := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{"completely", "different"}, nil
+	}
+
+	var seen map[string]any
+	f := func(ctx context.Context, args ...any) (int, error) {
+		seen = CarriedValues(ctx)
+		return len(args), nil
+	}
+
+	base := New[int](context.Background()).Then(produce)
+	args, _ := base.Args()
+
+	_, err := base.
+		Carry("order", args[0]).
+		Carry("total", args[1]).
+		Then(replace).
+		Carry("total", 43).
+		Finally(f)
+
+	if err != nil || len(seen) != 2 || seen["order"] != "order-1" || seen["total"] != 43 {
+		t.Fatalf("expected carried values in final func, got: %v, %v", seen, err)
+	}
+
+	// Carried values are not shared by chains with a common prefix
+	_, _ = base.Then(replace).Finally(f)
+	if len(seen) != 0 {
+		t.Fatalf("expected no carried values, got: %v", seen)
+	}
+
+	// The map returned is a copy
+	ctx := context.WithValue(context.Background(), carriedKey{}, map[string]any{"k": 1})
+	CarriedValues(ctx)["k"] = 2
+	if v := CarriedValues(ctx)["k"]; v != 1 {
+		t.Fatalf("expected carried value to be unaffected, got: %v", v)
+	}
+	if v := CarriedValues(context.Background()); v == nil || len(v) != 0 {
+		t.Fatalf("expected empty map, got: %v", v)
+	}
+}
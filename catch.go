@@ -0,0 +1,46 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNilCatchFunc is raised if a nil func is passed to Catch
+var ErrNilCatchFunc = errors.New("func provided to Catch cannot be nil")
+
+// Catch allows a failed chain to recover.  If the chain is in error then f is invoked with
+// the error, and should it succeed the chain resumes with the args it returns.  Should f
+// fail then the chain remains in error, with the error from f.  Chains that are not in
+// error are unaffected.  Compensations registered prior to the failure will already have
+// been run, and are not restored by recovery.
+func (c Chain[T]) Catch(f func(ctx context.Context, err error) ([]any, error)) Chain[T] {
+	return c.queue(f, check(ErrNilCatchFunc, f), func(c Chain[T]) Chain[T] {
+		if f == nil {
+			if c.err != nil {
+				return c
+			}
+			return c.fail(ErrNilCatchFunc)
+		}
+		if c.err == nil {
+			return c
+		}
+
+		cause := c.err
+		g := func(ctx context.Context, _ ...any) ([]any, error) {
+			return f(ctx, cause)
+		}
+
+		result, _, err := call(c.ctx, c.cfg.funcName(f), Retry{}, c.cfg.PanicMapper, c.cfg.PanicMode, g, nil)
+		if err != nil {
+			next := c
+			next.err = fmt.Errorf("error in %s: %w", c.cfg.funcName(f), err)
+			return next
+		}
+
+		next := c
+		next.err = nil
+		next.args = result
+		return next
+	})
+}
@@ -0,0 +1,99 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleChain_Catch() {
+
+	errNotFound := errors.New("not found")
+
+	lookup := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errNotFound
+	}
+
+	fallback := func(ctx context.Context, err error) ([]any, error) {
+		if errors.Is(err, errNotFound) {
+			return []any{"default"}, nil
+		}
+		return nil, err
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		return args[0].(string), nil
+	}
+
+	result, _ := New[string](context.Background(), "key").
+		Then(lookup).
+		Catch(fallback).
+		Finally(f)
+
+	fmt.Println("Result:", result)
+	// Output: Result: default
+}
+
+func TestChain_Catch(t *testing.T) {
+
+	errFailed := errors.New("failed")
+	errRethrown := errors.New("rethrown")
+
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errFailed
+	}
+
+	var caught error
+	rethrow := func(ctx context.Context, err error) ([]any, error) {
+		caught = err
+		return nil, errRethrown
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := New[int](context.Background(), 5).
+		Then(fail).
+		Catch(rethrow).
+		Finally(f)
+
+	if !errors.Is(caught, errFailed) {
+		t.Fatalf("expected Catch to receive the chain error, got: %v", caught)
+	}
+	if !errors.Is(err, errRethrown) || errors.Is(err, errFailed) {
+		t.Fatalf("expected rethrown error to replace the chain error, got: %v", err)
+	}
+
+	// Catch is not invoked for a successful chain
+	caught = nil
+	_, err = New[int](context.Background(), 5).
+		Catch(rethrow).
+		Finally(f)
+
+	if err != nil || caught != nil {
+		t.Fatalf("expected Catch to be skipped, got: %v, %v", err, caught)
+	}
+
+	boom := func(ctx context.Context, err error) ([]any, error) {
+		panic("Catch Boom!")
+	}
+
+	_, err = New[int](context.Background(), 5).
+		Then(fail).
+		Catch(boom).
+		Finally(f)
+
+	if !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected caught panic error, got: %v", err)
+	}
+
+	_, err = New[int](context.Background(), 5).
+		Catch(nil).
+		Finally(f)
+
+	if !errors.Is(err, ErrNilCatchFunc) {
+		t.Fatalf("expected nil catch error, got: %v", err)
+	}
+}
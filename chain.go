@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"math/rand"
 	"reflect"
 	"runtime"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -15,6 +18,13 @@ import (
 // checked during long running funcs.
 var ErrContextDone = errors.New("context is Done()")
 
+// errContextDone returns the error raised when the context is done prior to calling the
+// named func.  The context's cause is also wrapped, so that cancellation can be
+// distinguished from the deadline being exceeded.
+func errContextDone(ctx context.Context, name string) error {
+	return fmt.Errorf("prior to call to %s, %w: %w", name, ErrContextDone, context.Cause(ctx))
+}
+
 // Func is the type of func that can be passed to Chain.Then
 type Func func(context.Context, ...any) ([]any, error)
 
@@ -28,53 +38,279 @@ type Retry struct {
 	// BaseWait specifies the base sleep duration, which will be exponentially increased.
 	// Default = 10ms.  Max = 1s
 	BaseWait time.Duration
+	// MaxWait specifies the ceiling on any single sleep between attempts, at which the
+	// exponential backoff saturates.  Default = 30s.  Max = 5m
+	MaxWait time.Duration
+	// MaxElapsedTime, if > 0, is the budget for all attempts and the waits between them.
+	// No further attempts are made once the budget would be exceeded by the next wait.
+	MaxElapsedTime time.Duration
+	// AttemptTimeout, if > 0, limits each attempt, with the func's context done once it has
+	// elapsed.  An attempt that times out is retried regardless of Forward, ForwardTypes and
+	// RetryIf, unless the chain's own context is done.
+	AttemptTimeout time.Duration
+	// OnRetry, if not nil, is called before each wait between attempts, with the name of
+	// the func, the zero-based index of the attempt that failed, its error, and the
+	// duration about to be waited.  It is not called for errors that are forwarded.
+	OnRetry func(funcName string, attempt int, err error, nextWait time.Duration)
+	// Strategy determines the wait between attempts.  Default = ExponentialBackoff
+	Strategy Backoff
+	// Rand, if not nil, is the source of jitter for the default Strategy, allowing waits to
+	// be reproduced.  As a rand.Rand is not safe for concurrent use, it should not be shared
+	// by chains executing concurrently.  Default = the global math/rand source
+	Rand *rand.Rand
 	// Forward specifies the errors which if encountered, are to be forwarded with no retry attempt
 	// so that they are observable and acted upon.  The existence test uses via errors.Is().
-	// If nil or empty slice, then all errors are silently absorbed and the function retried
+	// If nil or empty slice, then all errors are silently absorbed and the function retried.
+	// A shared ErrorSet can be assigned here.
 	Forward []error
+	// ForwardTypes specifies error types which if encountered, are to be forwarded with no
+	// retry attempt, for errors identifiable only by type.  Each is a non-nil pointer to the
+	// type, as would be passed to errors.As(), such as new(*net.OpError).  The targets are
+	// not written to, so can be shared.  Both Forward and ForwardTypes are consulted.
+	ForwardTypes []any
+	// RetryIf, if not nil, determines which errors are retried, with all others forwarded
+	// with no retry attempt.  It takes precedence over Forward and ForwardTypes, which are
+	// not consulted when it is set.
+	RetryIf func(err error) bool
+}
+
+// errorType is the type of the error interface
+var errorType = reflect.TypeFor[error]()
+
+// isErrorTarget returns true if target is valid as the target of errors.As
+func isErrorTarget(target any) bool {
+	t := reflect.TypeOf(target)
+	if t == nil || t.Kind() != reflect.Pointer || reflect.ValueOf(target).IsNil() {
+		return false
+	}
+	return t.Elem().Kind() == reflect.Interface || t.Elem().Implements(errorType)
+}
+
+// forwards returns true if err is not to be retried, as determined by RetryIf if set,
+// otherwise by matching Forward or ForwardTypes
+func (r Retry) forwards(err error) bool {
+	if r.RetryIf != nil {
+		return !r.RetryIf(err)
+	}
+	for _, e := range r.Forward {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+	for _, target := range r.ForwardTypes {
+		// A new target is used for each test, so that the one provided is never written to
+		if errors.As(err, reflect.New(reflect.TypeOf(target).Elem()).Interface()) {
+			return true
+		}
+	}
+	return false
 }
 
 func (r Retry) ensureValid() Retry {
+	out, _ := r.Validate()
+	return out
+}
+
+// Validate returns the policy that will be applied, with defaults set and values clamped to
+// their allowed ranges, together with a description of each adjustment made to a value
+// that was explicitly set.  Chains apply these adjustments silently, unless created via
+// NewWithConfig with a Logger, to which the warnings are logged.
+func (r Retry) Validate() (Retry, []string) {
+	var warnings []string
+
 	out := r
 	if out.NumRetries < 0 {
+		warnings = append(warnings, fmt.Sprintf("NumRetries %d is below minimum, using 0", r.NumRetries))
 		out.NumRetries = 0
 	}
 	if out.NumRetries > 8 {
+		warnings = append(warnings, fmt.Sprintf("NumRetries %d exceeds maximum, using 8", r.NumRetries))
 		out.NumRetries = 8
 	}
 
+	if out.BaseWait < 0 {
+		warnings = append(warnings, fmt.Sprintf("BaseWait %v is negative, using default", r.BaseWait))
+	}
 	if out.BaseWait <= 0 {
 		out.BaseWait = 10 * time.Millisecond
 	}
 	if out.BaseWait > time.Second {
+		warnings = append(warnings, fmt.Sprintf("BaseWait %v exceeds maximum, using 1s", r.BaseWait))
 		out.BaseWait = time.Second
 	}
 
+	if out.MaxWait < 0 {
+		warnings = append(warnings, fmt.Sprintf("MaxWait %v is negative, using default", r.MaxWait))
+	}
+	if out.MaxWait <= 0 {
+		out.MaxWait = defaultMaxWait
+	}
+	if out.MaxWait > 5*time.Minute {
+		warnings = append(warnings, fmt.Sprintf("MaxWait %v exceeds maximum, using 5m0s", r.MaxWait))
+		out.MaxWait = 5 * time.Minute
+	}
+
+	if out.MaxElapsedTime < 0 {
+		warnings = append(warnings, fmt.Sprintf("MaxElapsedTime %v is negative, using no limit", r.MaxElapsedTime))
+		out.MaxElapsedTime = 0
+	}
+
+	if out.AttemptTimeout < 0 {
+		warnings = append(warnings, fmt.Sprintf("AttemptTimeout %v is negative, using no limit", r.AttemptTimeout))
+		out.AttemptTimeout = 0
+	}
+
 	out.Forward = []error{}
 	if r.Forward != nil {
 		out.Forward = append(out.Forward, r.Forward...)
 	}
 
+	out.ForwardTypes = nil
+	for _, target := range r.ForwardTypes {
+		if !isErrorTarget(target) {
+			warnings = append(warnings, fmt.Sprintf("ForwardTypes target %T is not a pointer to an error type, ignoring", target))
+			continue
+		}
+		out.ForwardTypes = append(out.ForwardTypes, target)
+	}
+
+	return out, warnings
+}
+
+// Config allows options to be set that apply to the whole chain
+type Config struct {
+	// Retry specifies the retry policy applied to each func in the chain
+	Retry Retry
+	// CompensationTimeout specifies the time allowed for compensations to complete when
+	// the chain fails.  Default = 30s
+	CompensationTimeout time.Duration
+	// Stats, if not nil, accumulates retry statistics for each func invoked by the chain
+	Stats *StatsCollector
+	// PanicMapper, if not nil, converts the value recovered from a panicking func into the
+	// error returned by the chain.  Default = the value formatted with ErrUnhandledPanic
+	PanicMapper PanicMapper
+	// PanicMode determines whether panics in funcs are recovered.  Default = PanicRecover
+	PanicMode PanicMode
+	// Logger, if not nil, receives warnings about failures that the chain absorbs, together
+	// with a debug record as each step starts and ends, and a warning should a step fail.
+	// Records identify the step by name and index, with those for the end of a step also
+	// holding the number of attempts made and the duration of the step.
+	Logger *slog.Logger
+	// OnChoice, if not nil, is called with the index of the func chosen by steps that
+	// select between funcs, such as ThenRoundRobin
+	OnChoice func(step string, choice int)
+	// After, if not nil, is called once each step completes, with the Timing of the step
+	// and its error, if any
+	After func(step string, timing Timing, err error)
+	// Observer, if not nil, is notified as each step starts and ends
+	Observer Observer
+	// Tracer, if not nil, traces each step
+	Tracer StepTracer
+	// CollectErrors, if true, allows the chain to continue past failing steps; see NewCollecting
+	CollectErrors bool
+	// ShortNames, if true, trims the package path from the runtime names of funcs reported
+	// in errors, so that github.com/org/pkg.Func is reported as pkg.Func
+	ShortNames bool
+	// Checkpointer, if not nil, saves the progress of the chain; see NewWithCheckpointer
+	Checkpointer Checkpointer
+	// ErrorMapper, if not nil, replaces the error of a failing step, which would otherwise
+	// be wrapped to identify the step.  It is called with the name of the step and its
+	// unwrapped error.  Returning nil recovers from the failure, with a Then step leaving
+	// the args unchanged and Finally returning the zero value of T.
+	ErrorMapper func(stepName string, err error) error
+}
+
+func (cfg Config) ensureValid() Config {
+	out := cfg
+	out.Retry = cfg.Retry.ensureValid()
+
+	if out.CompensationTimeout <= 0 {
+		out.CompensationTimeout = 30 * time.Second
+	}
+
 	return out
 }
 
-// Chain holds variadic args and tracks any error in the pipeline
+// Chain holds variadic args and tracks any error in the pipeline.
+//
+// Steps are not executed as they are added, but are accumulated until the chain is ended
+// by Finally or one of its variants, or its state is inspected via Args, Err, EncodeArgs or
+// Clone.  The steps are then executed in order, with the first failing step aborting the
+// chain.  A chain that is never ended does no work, and can be checked via DryRun first.
+// The outcome of executed steps is retained, so chains built from a common prefix execute
+// the prefix only once.
+//
+// A Chain is immutable, with each method returning a new Chain, so a Chain may be shared
+// between goroutines, each adding its own steps.  Each func receives its own copy of the
+// args, so a func may modify its args slice without affecting other chains, although the
+// values referenced by the args are not copied.
 type Chain[T any] struct {
-	ctx   context.Context
-	t     T
-	retry Retry
-	args  []any
-	err   error
+	ctx           context.Context
+	t             T
+	cfg           Config
+	args          []any
+	err           error
+	compensations []compensation
+	step          int // zero-based index of the next step, used to identify it in errors
+	collected     []error
+	pending       *node[T] // steps added but not yet executed, see resolve
+	middleware    []Middleware
+	short         bool // true once a step has short-circuited the chain, see ErrShortCircuit
+	resume        *checkpoint
 }
 
 // New starts a new pipeline with initial input values
 func New[T any](ctx context.Context, args ...any) Chain[T] {
-	return NewWithRetries[T](ctx, Retry{}, args...)
+	return NewWithConfig[T](ctx, Config{}, args...)
 }
 
 // NewWithRetries supports transitory failures via the configured retry options
 func NewWithRetries[T any](ctx context.Context, retry Retry, args ...any) Chain[T] {
-	return Chain[T]{ctx: ctx, args: args, retry: retry.ensureValid()}
+	return NewWithConfig[T](ctx, Config{Retry: retry}, args...)
+}
+
+// NewWithValues starts a new pipeline whose context carries the key/value pairs of kv,
+// making request-scoped data such as correlation IDs available to every step.  As with
+// context.WithValue, keys should be of unexported types to avoid collisions.
+func NewWithValues[T any](ctx context.Context, kv map[any]any, args ...any) Chain[T] {
+	for k, v := range kv {
+		ctx = context.WithValue(ctx, k, v)
+	}
+	return New[T](ctx, args...)
+}
+
+// NewWithTimeout starts a new pipeline that must complete within timeout, returning the
+// cancel func of the derived context, which should be deferred by the caller
+func NewWithTimeout[T any](ctx context.Context, timeout time.Duration, args ...any) (Chain[T], context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return New[T](ctx, args...), cancel
+}
+
+// NewCollecting starts a new pipeline in which failing steps do not abort the chain.  The
+// error from a failing step is collected and the chain continues with the args that the
+// step received.  Should any step have failed, Finally does not invoke its func, instead
+// returning the collected errors joined via errors.Join.  Errors that prevent the chain
+// continuing, such as the context being done, fail the chain immediately, again joined
+// with any errors already collected.
+func NewCollecting[T any](ctx context.Context, args ...any) Chain[T] {
+	return NewWithConfig[T](ctx, Config{CollectErrors: true}, args...)
+}
+
+// NewWithConfig starts a new pipeline using the configured options
+func NewWithConfig[T any](ctx context.Context, cfg Config, args ...any) Chain[T] {
+	if cfg.Logger != nil {
+		_, warnings := cfg.Retry.Validate()
+		for _, warning := range warnings {
+			cfg.warn(ctx, "retry policy adjusted", slog.String("adjustment", warning))
+		}
+	}
+	metrics.Load().chainStarted()
+
+	c := Chain[T]{ctx: ctx, args: args, cfg: cfg.ensureValid()}
+	if cfg.Checkpointer != nil {
+		c.resume = &checkpoint{}
+	}
+	return c
 }
 
 // Process is a single line equivalent for a chain call
@@ -84,8 +320,13 @@ func Process[T any](ctx context.Context, fs []Func, fn FinalFunc[T], args ...any
 
 // ProcessWithRetries is a single line equivalent for a chain call using retries
 func ProcessWithRetries[T any](ctx context.Context, fs []Func, fn FinalFunc[T], retry Retry, args ...any) (T, error) {
+	return ProcessWithConfig(ctx, fs, fn, Config{Retry: retry}, args...)
+}
+
+// ProcessWithConfig is a single line equivalent for a chain call using the configured options
+func ProcessWithConfig[T any](ctx context.Context, fs []Func, fn FinalFunc[T], cfg Config, args ...any) (T, error) {
 
-	var c = NewWithRetries[T](ctx, retry, args...)
+	var c = NewWithConfig[T](ctx, cfg, args...)
 
 	for _, f := range fs {
 		c = c.Then(f)
@@ -94,77 +335,276 @@ func ProcessWithRetries[T any](ctx context.Context, fs []Func, fn FinalFunc[T],
 	return c.Finally(fn)
 }
 
+// Args returns a copy of the chain's current args, or the chain's error if it has failed.
+// The copy is shallow, so values referenced by the args remain shared with the chain.
+func (c Chain[T]) Args() ([]any, error) {
+	c = c.resolve()
+	if c.err != nil {
+		return nil, c.err
+	}
+	return slices.Clone(c.args), nil
+}
+
+// Err returns the error of the chain, or nil if it has not failed, without affecting the
+// chain.  For a chain created by NewCollecting, the errors collected so far are returned,
+// joined via errors.Join.
+func (c Chain[T]) Err() error {
+	c = c.resolve()
+	if c.err != nil {
+		return c.err
+	}
+	return errors.Join(c.collected...)
+}
+
+// Clone returns an independent copy of the chain, with the same context, configuration,
+// args and error, so that it can be forked into variations.  The args slice is copied, but
+// the values it holds are not, so values such as pointers, maps and slices remain shared
+// by the original and the clone, and must not be mutated if the two are to be independent.
+// As the copy is taken of the chain's current args, any steps already added are executed.
+func (c Chain[T]) Clone() Chain[T] {
+	c = c.resolve()
+	next := c
+	next.args = slices.Clone(c.args)
+	next.compensations = slices.Clone(c.compensations)
+	next.collected = slices.Clone(c.collected)
+	next.middleware = slices.Clone(c.middleware)
+	return next
+}
+
 // ErrNilThenFunc is raised if a nil func is passsed to Then
 var ErrNilThenFunc = errors.New("func provided to Then cannot be nil")
 
 // Then adds a transformation step: func(...any) ([]any, error)
 func (c Chain[T]) Then(f Func) Chain[T] {
+	return c.queue(f, check(ErrNilThenFunc, f), func(c Chain[T]) Chain[T] {
+		return c.then(f, f)
+	})
+}
+
+// ThenWithRetry adds a transformation step with its own retry policy, which replaces the
+// chain's policy for f alone.  The policy is validated in the same way as for the chain.
+func (c Chain[T]) ThenWithRetry(f Func, retry Retry) Chain[T] {
+	return c.queue(f, check(ErrNilThenFunc, f), func(c Chain[T]) Chain[T] {
+		return c.thenWithRetry(f, f, retry.ensureValid())
+	})
+}
+
+// then invokes f, with any error attributed to named (see nameOf).  This allows
+// variants of Then to wrap the func provided whilst reporting errors against it.
+func (c Chain[T]) then(f Func, named any) Chain[T] {
+	return c.thenWithRetry(f, named, c.cfg.Retry)
+}
+
+// thenWithRetry is then, with the retry policy applied to f alone
+func (c Chain[T]) thenWithRetry(f Func, named any, retry Retry) Chain[T] {
+	next, _ := c.thenSucceeded(f, named, retry)
+	return next
+}
+
+// thenSucceeded is thenWithRetry, additionally returning true only if f was invoked and
+// succeeded.  It is false should the step fail, even if the chain continues because the
+// error was collected or replaced by nil by the ErrorMapper, and false should the step be
+// skipped, having been completed prior to the chain's checkpoint.
+func (c Chain[T]) thenSucceeded(f Func, named any, retry Retry) (Chain[T], bool) {
 	if c.err != nil {
-		return c
+		return c, false
 	}
 	if f == nil {
-		return Chain[T]{err: ErrNilThenFunc}
+		return c.fail(ErrNilThenFunc), false
+	}
+	if next, ok := c.restore(); ok {
+		return next, false
 	}
 
 	select {
 	case <-c.ctx.Done():
-		funcName := runtimeFuncName(f)
-		return Chain[T]{err: fmt.Errorf("prior to call to %s, %w", funcName, ErrContextDone)}
+		return c.fail(errContextDone(c.ctx, c.stepName(named))), false
 	default:
-		result, err := c.thenWrap(f)
+		var short bool
+		g := c.wrap(f)
+		result, err := c.thenWrap(func(ctx context.Context, args ...any) ([]any, error) {
+			result, err := g(ctx, args...)
+			if errors.Is(err, ErrShortCircuit) {
+				short = true
+				return result, nil
+			}
+			return result, err
+		}, retry, named)
+		succeeded := err == nil
 		if err != nil {
-			funcName := runtimeFuncName(f)
-			return Chain[T]{err: fmt.Errorf("error in %s: %w", funcName, err)}
+			if err = c.stepError(named, err); err != nil {
+				if c.cfg.CollectErrors {
+					next := c
+					next.collected = append(slices.Clone(c.collected), err)
+					next.step++
+					return next, false
+				}
+				return c.fail(err), false
+			}
+			result = c.args
 		}
 
-		return Chain[T]{args: result, t: c.t, ctx: c.ctx, retry: c.retry}
+		next := c
+		next.args = result
+		next.step++
+		next.short = short
+		return c.save(next), succeeded
 	}
 }
 
+// fail moves the chain into its error state, running any compensations.  Any errors
+// collected from earlier steps are joined with err.
+func (c Chain[T]) fail(err error) Chain[T] {
+	if len(c.collected) > 0 {
+		err = errors.Join(append(slices.Clone(c.collected), err)...)
+	}
+
+	next := c
+	next.args = nil
+	next.collected = nil
+	next.err = c.compensate(err)
+	next.compensations = nil
+	return next
+}
+
 // ErrUnhandledPanic raised if funcs panic when invoked by Then or Finally
 var ErrUnhandledPanic = errors.New("unhandled panic")
 
 // ErrExceededRetries raised if the func repeatedly returns error.  Note that if the
-// func panics then retries are not attempted
+// func panics then retries are not attempted.  The error returned wraps both
+// ErrExceededRetries and the error from the last attempt, in that order, so that
+// errors.Is can be used to test for either.
 var ErrExceededRetries = errors.New("exceeded retry count")
 
-func (c Chain[T]) thenWrap(f Func) (result []any, err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			result = nil
-			err = fmt.Errorf("%v: %w", r, ErrUnhandledPanic)
-		}
-	}()
+func (c Chain[T]) thenWrap(f Func, retry Retry, named any) ([]any, error) {
+	logged := c.logStep(named)
+	end := c.observe(named)
+	ctx, finish := c.trace(c.ctx, named)
+	ctx, f, done := timed(ctx, c.cfg, named, f)
+	result, attempts, err := call(ctx, c.cfg.nameOf(named), retry, c.cfg.PanicMapper, c.cfg.PanicMode, f, slices.Clone(c.args))
+	c.cfg.Stats.record(named, attempts, err)
+	metrics.Load().stepRun(attempts)
+	done(attempts, err)
+	finish(attempts, err)
+	end(err)
+	logged(attempts, err)
+	return result, err
+}
 
-	attempt := 0
-	for range 1 + c.retry.NumRetries {
-		if result, err := f(c.ctx, c.args...); err == nil {
-			return result, err
+// call invokes f according to the retry policy, returning the number of attempts made.
+// Should f panic then no further attempts are made, with the panic recovered unless mode
+// is PanicPropagate.  No further attempts are made should the next wait extend beyond the
+// deadline of ctx.  name identifies f to Retry.OnRetry.
+func call[R any](ctx context.Context, name string, retry Retry, panics PanicMapper, mode PanicMode, f func(context.Context, ...any) (R, error), args []any) (result R, attempts int, err error) {
+	var zero R
+
+	if mode != PanicPropagate {
+		defer func() {
+			if r := recover(); r != nil {
+				metrics.Load().panicked()
+				result = zero
+				err = panics.toError(name, r)
+			}
+		}()
+	}
+
+	start := time.Now()
+
+	for range 1 + retry.NumRetries {
+		attempts++
+		if result, timedOut, err := attempt(ctx, retry.AttemptTimeout, f, args); err == nil {
+			return result, attempts, err
 		} else {
-			if c.retry.NumRetries == 0 {
-				return nil, err
+			if retry.NumRetries == 0 {
+				return zero, attempts, err
 			}
-			for _, e := range c.retry.Forward {
-				if errors.Is(err, e) {
-					return nil, err
-				}
+			if !timedOut && retry.forwards(err) {
+				return zero, attempts, err
+			}
+
+			if attempts > retry.NumRetries {
+				return zero, attempts, fmt.Errorf("%w: %w", ErrExceededRetries, err)
+			}
+
+			wait := retry.wait(attempts - 1)
+			if retry.MaxElapsedTime > 0 && time.Since(start)+wait > retry.MaxElapsedTime {
+				return zero, attempts, fmt.Errorf("%w after %v: %w", ErrExceededRetries, time.Since(start), err)
+			}
+			if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+				// The context would be done before the next attempt, so there is no value in waiting
+				return zero, attempts, fmt.Errorf("%w before context deadline: %w", ErrExceededRetries, err)
+			}
+			if retry.OnRetry != nil {
+				retry.OnRetry(name, attempts-1, err, wait)
+			}
+			if !sleep(ctx, wait) {
+				return zero, attempts, fmt.Errorf("during retry wait, %w: %w", ErrContextDone, context.Cause(ctx))
 			}
 		}
+	}
+
+	return zero, attempts, ErrExceededRetries // Unreachable, as the final attempt returns
+}
 
-		c.sleep(attempt)
-		attempt++
+// attempt calls f once, with a context limited to timeout if > 0, reporting whether the
+// limit was reached whilst ctx itself remained live
+func attempt[R any](ctx context.Context, timeout time.Duration, f func(context.Context, ...any) (R, error), args []any) (R, bool, error) {
+	if timeout <= 0 {
+		result, err := f(ctx, args...)
+		return result, false, err
 	}
 
-	return nil, ErrExceededRetries
+	actx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := f(actx, args...)
+	return result, err != nil && actx.Err() != nil && ctx.Err() == nil, err
 }
 
-func (c Chain[T]) sleep(attempt int) {
-	backoff := c.retry.BaseWait * (1 << attempt) // 2^attempt
+// invoke calls f, converting any panic into an error.  This is required when f is
+// called from a separate goroutine, outside of the recovery provided by call.
+func invoke(ctx context.Context, name string, panics PanicMapper, f Func, args []any) (result []any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			metrics.Load().panicked()
+			result = nil
+			err = panics.toError(name, r)
+		}
+	}()
 
-	jitter := time.Duration(rand.Int63n(int64(backoff / 2)))
-	sleep := backoff + jitter
+	return f(ctx, args...)
+}
+
+const defaultMaxWait = 30 * time.Second
+
+// wait returns the backoff following the attempt, as determined by Strategy and saturating
+// at MaxWait
+func (r Retry) wait(attempt int) time.Duration {
+	maxWait := r.MaxWait
+	if maxWait <= 0 {
+		maxWait = defaultMaxWait
+	}
 
-	<-time.After(sleep)
+	var strategy Backoff = ExponentialBackoff{Rand: r.Rand}
+	if r.Strategy != nil {
+		strategy = r.Strategy
+	}
+
+	return min(max(strategy.NextWait(attempt, r.BaseWait), 0), maxWait)
+}
+
+// sleep waits for the duration, returning false if the context is done before the wait
+// completes
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
 }
 
 // ErrNilFinalFunc is raised if a nil func is passsed to Finally
@@ -172,61 +612,109 @@ var ErrNilFinalFunc = errors.New("func provided to Finally cannot be nil")
 
 // Finally is a generic method on Chain that ends the pipeline
 func (c Chain[T]) Finally(f FinalFunc[T]) (T, error) {
+	return c.finally(f, f)
+}
+
+// finally invokes f to end the pipeline, with any error attributed to named (see nameOf)
+func (c Chain[T]) finally(f FinalFunc[T], named any) (_ T, err error) {
+	if m := metrics.Load(); m != nil {
+		defer func() { m.ended(err) }()
+	}
+
+	c = c.resolve()
 	if c.err != nil {
 		return c.t, c.err
 	}
 	if f == nil {
-		return c.t, ErrNilFinalFunc
+		return c.t, c.compensate(ErrNilFinalFunc)
+	}
+	if len(c.collected) > 0 {
+		return c.t, c.compensate(errors.Join(c.collected...))
 	}
 
 	select {
 	case <-c.ctx.Done():
-		funcName := runtimeFuncName(f)
-		return c.t, fmt.Errorf("prior to call to %s, %w", funcName, ErrContextDone)
+		return c.t, c.compensate(errContextDone(c.ctx, c.stepName(named)))
 	default:
 
-		result, err := c.finallyWrap(f)
+		result, err := c.finallyWrap(f, named)
 		if err != nil {
-			funcName := runtimeFuncName(f)
-			return c.t, fmt.Errorf("error in %s: %w", funcName, err)
+			if err = c.stepError(named, err); err != nil {
+				return c.t, c.compensate(err)
+			}
 		}
 
 		return result, nil
 	}
 }
 
-func (c Chain[T]) finallyWrap(f FinalFunc[T]) (result T, err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			var zero T
-			result = zero
-			err = fmt.Errorf("%v: %w", r, ErrUnhandledPanic)
-		}
-	}()
+func (c Chain[T]) finallyWrap(f FinalFunc[T], named any) (T, error) {
+	logged := c.logStep(named)
+	end := c.observe(named)
+	ctx, finish := c.trace(c.ctx, named)
+	ctx, f, done := timed(ctx, c.cfg, named, f)
+	result, attempts, err := call(ctx, c.cfg.nameOf(named), c.cfg.Retry, c.cfg.PanicMapper, c.cfg.PanicMode, f, slices.Clone(c.args))
+	c.cfg.Stats.record(named, attempts, err)
+	metrics.Load().stepRun(attempts)
+	done(attempts, err)
+	finish(attempts, err)
+	end(err)
+	logged(attempts, err)
+	return result, err
+}
 
-	attempt := 0
-	for range 1 + c.retry.NumRetries {
-		if result, err := f(c.ctx, c.args...); err == nil {
-			return result, err
-		} else {
-			if c.retry.NumRetries == 0 {
-				return c.t, err
-			}
-			for _, e := range c.retry.Forward {
-				if errors.Is(err, e) {
-					return c.t, err
-				}
-			}
-		}
+// stepError returns the error for a failing step, identifying the step unless replaced by
+// the ErrorMapper
+func (c Chain[T]) stepError(named any, err error) error {
+	if c.cfg.ErrorMapper != nil {
+		return c.cfg.ErrorMapper(c.cfg.nameOf(named), err)
+	}
+	return fmt.Errorf("error in %s: %w", c.stepName(named), err)
+}
+
+// stepName identifies the next step in errors, by its index and the name of named
+func (c Chain[T]) stepName(named any) string {
+	return fmt.Sprintf("step %d (%s)", c.step, c.cfg.nameOf(named))
+}
 
-		c.sleep(attempt)
-		attempt++
+// nameOf is the package level nameOf, shortened if ShortNames is set
+func (cfg Config) nameOf(named any) string {
+	if s, ok := named.(string); ok {
+		return s
 	}
+	return cfg.funcName(named)
+}
 
-	return c.t, ErrExceededRetries
+// funcName is runtimeFuncName, shortened if ShortNames is set
+func (cfg Config) funcName(fn any) string {
+	name := runtimeFuncName(fn)
+	if cfg.ShortNames {
+		name = name[strings.LastIndex(name, "/")+1:]
+	}
+	return name
 }
 
+// Helper to get the name used for debug/error reporting, which is either
+// the string provided or the runtime name of the func
+func nameOf(named any) string {
+	if s, ok := named.(string); ok {
+		return s
+	}
+	return runtimeFuncName(named)
+}
+
+// unknownFuncName is reported in place of the name of a value that is not a func, or whose
+// name is unavailable
+const unknownFuncName = "<unknown>"
+
 // Helper to get function name for debug/error reporting
 func runtimeFuncName(fn interface{}) string {
-	return runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return unknownFuncName
+	}
+	if f := runtime.FuncForPC(v.Pointer()); f != nil {
+		return f.Name()
+	}
+	return unknownFuncName
 }
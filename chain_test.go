@@ -1,9 +1,16 @@
 package chain
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -92,7 +99,7 @@ func ExampleNewWithRetries() {
 		Finally(return99)
 
 	fmt.Println("Err:", err)
-	// Output: Err: error in github.com/gford1000-go/chain.ExampleNewWithRetries.func1: exceeded retry count
+	// Output: Err: error in step 0 (github.com/gford1000-go/chain.ExampleNewWithRetries.func1): exceeded retry count: failed
 }
 
 func ExampleNewWithRetries_forward() {
@@ -119,7 +126,7 @@ func ExampleNewWithRetries_forward() {
 		Finally(return99)
 
 	fmt.Println("Err:", err)
-	// Output: Err: error in github.com/gford1000-go/chain.ExampleNewWithRetries_forward.func1: failed
+	// Output: Err: error in step 0 (github.com/gford1000-go/chain.ExampleNewWithRetries_forward.func1): failed
 }
 
 func ExampleNewWithRetries_panic() {
@@ -143,7 +150,7 @@ func ExampleNewWithRetries_panic() {
 		Finally(return99)
 
 	fmt.Println("Err:", err)
-	// Output: Err: error in github.com/gford1000-go/chain.ExampleNewWithRetries_panic.func1: Boom!: unhandled panic
+	// Output: Err: error in step 0 (github.com/gford1000-go/chain.ExampleNewWithRetries_panic.func1): Boom!: unhandled panic
 }
 
 func ExampleNew_failure() {
@@ -185,7 +192,7 @@ func ExampleNew_failure() {
 	}
 
 	fmt.Println("Result:", result)
-	// Output: error in github.com/gford1000-go/chain.ExampleNew_failure.func3: x became negative
+	// Output: error in step 2 (github.com/gford1000-go/chain.ExampleNew_failure.func3): x became negative
 }
 
 func TestNew(t *testing.T) {
@@ -406,3 +413,931 @@ func TestChain_Finally(t *testing.T) {
 		t.Fatalf("expected caught panic error, got: %v", err)
 	}
 }
+
+func TestRetry_Validate(t *testing.T) {
+
+	r, warnings := Retry{NumRetries: 3, BaseWait: 0}.Validate()
+	if len(warnings) != 0 {
+		t.Fatalf("expected defaults to be applied silently, got: %v", warnings)
+	}
+	if r.NumRetries != 3 || r.BaseWait != 10*time.Millisecond {
+		t.Fatalf("unexpected policy, got: %+v", r)
+	}
+
+	r, warnings = Retry{NumRetries: 100, BaseWait: time.Minute}.Validate()
+	if r.NumRetries != 8 || r.BaseWait != time.Second {
+		t.Fatalf("expected values to be clamped, got: %+v", r)
+	}
+	if len(warnings) != 2 ||
+		warnings[0] != "NumRetries 100 exceeds maximum, using 8" ||
+		warnings[1] != "BaseWait 1m0s exceeds maximum, using 1s" {
+		t.Fatalf("unexpected warnings, got: %q", warnings)
+	}
+
+	_, warnings = Retry{NumRetries: -1, BaseWait: -time.Second}.Validate()
+	if len(warnings) != 2 {
+		t.Fatalf("unexpected warnings, got: %q", warnings)
+	}
+}
+
+func TestNewWithConfig(t *testing.T) {
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	NewWithConfig[int](context.Background(), Config{Retry: Retry{NumRetries: 100}, Logger: logger})
+
+	if !strings.Contains(buf.String(), "NumRetries 100 exceeds maximum, using 8") {
+		t.Fatalf("expected warning to be logged, got: %s", buf.String())
+	}
+}
+
+func TestRetry_sleep(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempted := make(chan struct{}, 1)
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		select {
+		case attempted <- struct{}{}:
+		default:
+		}
+		return nil, errors.New("failed")
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	go func() {
+		<-attempted
+		<-time.After(5 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+
+	_, err := NewWithRetries[int](ctx, Retry{NumRetries: 8, BaseWait: time.Second}).
+		Then(fail).
+		Finally(f)
+
+	if !errors.Is(err, ErrContextDone) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context done error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "TestRetry_sleep.func1") {
+		t.Fatalf("expected error to identify the func, got: %v", err)
+	}
+	if d := time.Since(start); d > 100*time.Millisecond {
+		t.Fatalf("expected retry wait to be interrupted, took: %v", d)
+	}
+}
+
+func TestRetry_sleep_1(t *testing.T) {
+
+	// Bypassing Validate allows a zero or near-zero BaseWait, which must not panic
+	for _, base := range []time.Duration{0, 1} {
+		if !sleep(context.Background(), (Retry{BaseWait: base}).wait(0)) {
+			t.Fatalf("expected sleep to complete for BaseWait %v", base)
+		}
+	}
+}
+
+func TestRetry_wait(t *testing.T) {
+
+	r := Retry{NumRetries: 8, BaseWait: time.Second, MaxWait: 20 * time.Second}.ensureValid()
+
+	for attempt := range 9 {
+		w := r.wait(attempt)
+		if w < 0 || w > r.MaxWait {
+			t.Fatalf("attempt %d: expected wait within [0, %v], got: %v", attempt, r.MaxWait, w)
+		}
+	}
+	if w := r.wait(8); w != r.MaxWait {
+		t.Fatalf("expected backoff to saturate at MaxWait, got: %v", w)
+	}
+
+	// Even without validation, a huge BaseWait and attempt must not overflow
+	r = Retry{BaseWait: time.Duration(1 << 62)}
+	if w := r.wait(63); w != defaultMaxWait {
+		t.Fatalf("expected backoff to saturate at default MaxWait, got: %v", w)
+	}
+
+	r, warnings := Retry{MaxWait: time.Hour}.Validate()
+	if r.MaxWait != 5*time.Minute || len(warnings) != 1 {
+		t.Fatalf("expected MaxWait to be clamped, got: %v, %q", r.MaxWait, warnings)
+	}
+}
+
+func TestChain_ThenWithRetry(t *testing.T) {
+
+	errFailed := errors.New("failed")
+
+	counts := map[string]int{}
+	flaky := func(name string, failures int) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			counts[name]++
+			if counts[name] <= failures {
+				return nil, errFailed
+			}
+			return args, nil
+		}
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	result, err := New[int](context.Background(), 5).
+		Then(flaky("before", 0)).
+		ThenWithRetry(flaky("network", 3), Retry{NumRetries: 3, BaseWait: time.Millisecond}).
+		Then(flaky("after", 0)).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if result != 5 || counts["network"] != 4 {
+		t.Fatalf("expected step to be retried, got: %v after %d attempts", result, counts["network"])
+	}
+
+	// Surrounding plain steps continue to fail fast
+	_, err = New[int](context.Background(), 5).
+		ThenWithRetry(flaky("other", 0), Retry{NumRetries: 3, BaseWait: time.Millisecond}).
+		Then(flaky("fast", 1)).
+		Finally(f)
+
+	if !errors.Is(err, errFailed) || counts["fast"] != 1 {
+		t.Fatalf("expected plain step not to be retried, got: %v after %d attempts", err, counts["fast"])
+	}
+
+	// Forward is honoured by the step policy
+	counts = map[string]int{}
+	_, err = New[int](context.Background(), 5).
+		ThenWithRetry(flaky("forward", 3), Retry{NumRetries: 3, BaseWait: time.Millisecond, Forward: []error{errFailed}}).
+		Finally(f)
+
+	if !errors.Is(err, errFailed) || counts["forward"] != 1 {
+		t.Fatalf("expected forwarded error not to be retried, got: %v after %d attempts", err, counts["forward"])
+	}
+}
+
+func TestRetry_OnRetry(t *testing.T) {
+
+	errFailed := errors.New("failed")
+
+	var calls int
+	flaky := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		if calls <= 2 {
+			return nil, errFailed
+		}
+		return args, nil
+	}
+
+	type retried struct {
+		name    string
+		attempt int
+		err     error
+		wait    time.Duration
+	}
+
+	var observed []retried
+	retry := Retry{
+		NumRetries: 3,
+		BaseWait:   time.Millisecond,
+		OnRetry: func(funcName string, attempt int, err error, nextWait time.Duration) {
+			observed = append(observed, retried{funcName, attempt, err, nextWait})
+		},
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	_, err := NewWithRetries[int](context.Background(), retry, 5).
+		Then(flaky).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if len(observed) != 2 {
+		t.Fatalf("expected two retries to be observed, got: %v", observed)
+	}
+	for i, o := range observed {
+		if o.attempt != i || o.err != errFailed || o.wait <= 0 || !strings.HasSuffix(o.name, "TestRetry_OnRetry.func1") {
+			t.Fatalf("unexpected observation %d, got: %+v", i, o)
+		}
+	}
+
+	// Forwarded errors are not observed
+	observed = nil
+	calls = 0
+	retry.Forward = []error{errFailed}
+
+	_, err = NewWithRetries[int](context.Background(), retry, 5).
+		Then(flaky).
+		Finally(f)
+
+	if !errors.Is(err, errFailed) || len(observed) != 0 {
+		t.Fatalf("expected forwarded error with no retries, got: %v, %v", err, observed)
+	}
+}
+
+func TestRetry_Rand(t *testing.T) {
+
+	sequence := func() []time.Duration {
+		r := Retry{NumRetries: 4, BaseWait: 10 * time.Millisecond, Rand: rand.New(rand.NewSource(42))}.ensureValid()
+		var out []time.Duration
+		for attempt := range r.NumRetries {
+			out = append(out, r.wait(attempt))
+		}
+		return out
+	}
+
+	src := rand.New(rand.NewSource(42))
+	var want []time.Duration
+	for attempt := range 4 {
+		backoff := (10 * time.Millisecond) << attempt
+		want = append(want, backoff+time.Duration(src.Int63n(int64(backoff/2))))
+	}
+
+	if got := sequence(); !slices.Equal(got, want) {
+		t.Fatalf("unexpected waits.  wanted: %v, got: %v", want, got)
+	}
+	if a, b := sequence(), sequence(); !slices.Equal(a, b) {
+		t.Fatalf("expected reproducible waits, got: %v and %v", a, b)
+	}
+}
+
+func TestRetry_MaxElapsedTime(t *testing.T) {
+
+	errFailed := errors.New("failed")
+
+	var attempts int
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		attempts++
+		return nil, errFailed
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	retry := Retry{
+		NumRetries:     8,
+		BaseWait:       20 * time.Millisecond,
+		MaxElapsedTime: 50 * time.Millisecond,
+		Strategy:       ConstantBackoff{},
+	}
+
+	start := time.Now()
+
+	_, err := NewWithRetries[int](context.Background(), retry).
+		Then(fail).
+		Finally(f)
+
+	if !errors.Is(err, ErrExceededRetries) || !errors.Is(err, errFailed) {
+		t.Fatalf("expected exceeded retries with the last error, got: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected budget to allow 3 attempts, got: %d", attempts)
+	}
+	if d := time.Since(start); d > 100*time.Millisecond {
+		t.Fatalf("expected budget to be respected, took: %v", d)
+	}
+
+	// A long BaseWait exhausts the budget immediately
+	attempts = 0
+	retry = Retry{NumRetries: 8, BaseWait: time.Second, MaxElapsedTime: 100 * time.Millisecond}
+
+	start = time.Now()
+
+	_, err = NewWithRetries[int](context.Background(), retry).
+		Then(fail).
+		Finally(f)
+
+	if !errors.Is(err, ErrExceededRetries) || attempts != 1 {
+		t.Fatalf("expected to give up after a single attempt, got: %v after %d", err, attempts)
+	}
+	if d := time.Since(start); d > 50*time.Millisecond {
+		t.Fatalf("expected to give up early, took: %v", d)
+	}
+}
+
+func TestChain_Then_1(t *testing.T) {
+
+	errFailed := errors.New("failed")
+
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errFailed
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := NewWithRetries[int](context.Background(), Retry{NumRetries: 2, BaseWait: time.Millisecond}).
+		Then(fail).
+		Finally(f)
+
+	if !errors.Is(err, ErrExceededRetries) {
+		t.Fatalf("expected exceeded retries error, got: %v", err)
+	}
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("expected underlying error, got: %v", err)
+	}
+}
+
+func TestChain_Then_2(t *testing.T) {
+
+	pass := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errors.New("failed")
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	for n := range 4 {
+		c := New[int](context.Background())
+		for range n {
+			c = c.Then(pass)
+		}
+
+		_, err := c.ThenNamed("fail", fail).Finally(f)
+
+		if want := fmt.Sprintf("error in step %d (fail): failed", n); err == nil || err.Error() != want {
+			t.Fatalf("unexpected error.  wanted: %s, got: %v", want, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := New[int](ctx).
+		Finally(f)
+
+	if err == nil || !strings.HasPrefix(err.Error(), "prior to call to step 0 (") {
+		t.Fatalf("expected step index in context done error, got: %v", err)
+	}
+}
+
+func TestChain_Args(t *testing.T) {
+
+	inc := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0].(int) + 1}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	c := New[int](context.Background(), 1).Then(inc)
+
+	args, err := c.Args()
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if len(args) != 1 || args[0] != 2 {
+		t.Fatalf("unexpected args, got: %v", args)
+	}
+
+	args[0] = 100
+
+	result, err := c.Then(inc).Finally(f)
+	if err != nil || result != 3 {
+		t.Fatalf("expected chain to be unaffected by mutation, got: %v, %v", result, err)
+	}
+
+	errFailed := errors.New("failed")
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errFailed
+	}
+
+	if _, err := c.Then(fail).Args(); !errors.Is(err, errFailed) {
+		t.Fatalf("expected chain error, got: %v", err)
+	}
+}
+
+func TestNewWithTimeout(t *testing.T) {
+
+	slow := func(ctx context.Context, args ...any) ([]any, error) {
+		<-time.After(20 * time.Millisecond)
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	c, cancel := NewWithTimeout[int](context.Background(), 5*time.Millisecond, 5)
+	defer cancel()
+
+	_, err := c.Then(slow).Then(slow).Finally(f)
+
+	if !errors.Is(err, ErrContextDone) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context done error, got: %v", err)
+	}
+
+	c, cancel = NewWithTimeout[int](context.Background(), time.Second, 5)
+
+	result, err := c.Finally(f)
+	if err != nil || result != 5 {
+		t.Fatalf("unexpected result, got: %v, %v", result, err)
+	}
+
+	// Safe to call after completion, and more than once
+	cancel()
+	cancel()
+}
+
+func TestChain_Clone(t *testing.T) {
+
+	add := func(n int) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			args[0] = args[0].(int) + n
+			return args, nil
+		}
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	base := New[int](context.Background(), 1).Then(add(1))
+	fork := base.Clone()
+
+	a, errA := base.Then(add(10)).Finally(f)
+	b, errB := fork.Then(add(100)).Finally(f)
+
+	if errA != nil || errB != nil {
+		t.Fatalf("unexpected errors, got: %v, %v", errA, errB)
+	}
+	if a != 12 || b != 102 {
+		t.Fatalf("expected chains to diverge, got: %d, %d", a, b)
+	}
+
+	errFailed := errors.New("failed")
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errFailed
+	}
+
+	if _, err := base.Then(fail).Clone().Finally(f); !errors.Is(err, errFailed) {
+		t.Fatalf("expected clone to carry the error, got: %v", err)
+	}
+}
+
+func TestChain_Err(t *testing.T) {
+
+	errFailed := errors.New("failed")
+
+	pass := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errFailed
+	}
+
+	c := New[int](context.Background()).Then(pass)
+	if err := c.Err(); err != nil {
+		t.Fatalf("expected healthy chain, got: %v", err)
+	}
+
+	c = c.Then(fail)
+	if err := c.Err(); !errors.Is(err, errFailed) || !strings.HasPrefix(err.Error(), "error in step 1 (") {
+		t.Fatalf("expected wrapped step error, got: %v", err)
+	}
+
+	c = NewCollecting[int](context.Background()).Then(fail).Then(pass)
+	if err := c.Err(); !errors.Is(err, errFailed) {
+		t.Fatalf("expected collected error, got: %v", err)
+	}
+}
+
+func TestNewWithValues(t *testing.T) {
+
+	type correlationKey struct{}
+	type tenantKey struct{}
+
+	var seen []any
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		seen = append(seen, ctx.Value(tenantKey{}))
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		id, _ := ctx.Value(correlationKey{}).(string)
+		return id, nil
+	}
+
+	kv := map[any]any{
+		correlationKey{}: "req-42",
+		tenantKey{}:      "acme",
+	}
+
+	result, err := NewWithValues[string](context.Background(), kv).
+		Then(step).
+		Then(step).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if result != "req-42" {
+		t.Fatalf("expected correlation ID in final func, got: %q", result)
+	}
+	if len(seen) != 2 || seen[0] != "acme" || seen[1] != "acme" {
+		t.Fatalf("expected value in every step, got: %v", seen)
+	}
+}
+
+func TestRuntimeFuncName(t *testing.T) {
+
+	var nilFunc Func
+	var nilIface any
+
+	tests := []struct {
+		name string
+		fn   any
+		want string
+	}{
+		{"nil interface", nilIface, "<unknown>"},
+		{"nil func", nilFunc, "<unknown>"},
+		{"int", 42, "<unknown>"},
+		{"string", "step", "<unknown>"},
+		{"pointer", new(int), "<unknown>"},
+	}
+
+	for _, test := range tests {
+		if got := runtimeFuncName(test.fn); got != test.want {
+			t.Fatalf("%s: expected %q, got: %q", test.name, test.want, got)
+		}
+	}
+
+	if got := runtimeFuncName(TestRuntimeFuncName); !strings.HasSuffix(got, ".TestRuntimeFuncName") {
+		t.Fatalf("expected runtime name of func, got: %q", got)
+	}
+}
+
+func TestConfig_ShortNames(t *testing.T) {
+
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errors.New("failed")
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	full := runtimeFuncName(fail)
+	if !strings.HasPrefix(full, "github.com/gford1000-go/chain.") {
+		t.Fatalf("expected fully qualified name, got: %q", full)
+	}
+	short := strings.TrimPrefix(full, "github.com/gford1000-go/")
+
+	_, err := NewWithConfig[int](context.Background(), Config{}).Then(fail).Finally(f)
+	if want := "error in step 0 (" + full + "): failed"; err == nil || err.Error() != want {
+		t.Fatalf("expected %q, got: %v", want, err)
+	}
+
+	_, err = NewWithConfig[int](context.Background(), Config{ShortNames: true}).Then(fail).Finally(f)
+	if want := "error in step 0 (" + short + "): failed"; err == nil || err.Error() != want {
+		t.Fatalf("expected %q, got: %v", want, err)
+	}
+
+	// Names provided explicitly are not shortened
+	_, err = NewWithConfig[int](context.Background(), Config{ShortNames: true}).ThenNamed("a/b", fail).Finally(f)
+	if want := "error in step 0 (a/b): failed"; err == nil || err.Error() != want {
+		t.Fatalf("expected %q, got: %v", want, err)
+	}
+}
+
+type opError struct {
+	op string
+}
+
+func (e *opError) Error() string {
+	return "op failed: " + e.op
+}
+
+func TestRetry_ForwardTypes(t *testing.T) {
+
+	var calls int
+	failWith := func(err error) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			calls++
+			return nil, err
+		}
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	target := new(*opError)
+	retry := Retry{NumRetries: 2, BaseWait: time.Nanosecond, ForwardTypes: []any{target}}
+
+	_, err := NewWithRetries[int](context.Background(), retry).Then(failWith(fmt.Errorf("dial: %w", &opError{op: "dial"}))).Finally(f)
+
+	var opErr *opError
+	if calls != 1 || !errors.As(err, &opErr) || errors.Is(err, ErrExceededRetries) {
+		t.Fatalf("expected error type to be forwarded without retry, got: %d, %v", calls, err)
+	}
+	if *target != nil {
+		t.Fatalf("expected target not to be written to, got: %v", *target)
+	}
+
+	calls = 0
+	_, err = NewWithRetries[int](context.Background(), retry).Then(failWith(errors.New("transient"))).Finally(f)
+	if calls != 3 || !errors.Is(err, ErrExceededRetries) {
+		t.Fatalf("expected other errors to be retried, got: %d, %v", calls, err)
+	}
+
+	// Forward and ForwardTypes are both consulted
+	errForwarded := errors.New("forwarded")
+	retry.Forward = []error{errForwarded}
+
+	calls = 0
+	_, err = NewWithRetries[int](context.Background(), retry).Then(failWith(errForwarded)).Finally(f)
+	if calls != 1 || !errors.Is(err, errForwarded) {
+		t.Fatalf("expected error value to be forwarded without retry, got: %d, %v", calls, err)
+	}
+}
+
+func TestRetry_ForwardTypes_1(t *testing.T) {
+
+	var nilTarget *error
+	out, warnings := Retry{ForwardTypes: []any{nil, opError{}, new(int), nilTarget, new(*opError), new(error)}}.Validate()
+
+	if len(out.ForwardTypes) != 2 || len(warnings) != 4 {
+		t.Fatalf("expected invalid targets to be dropped with warnings, got: %v, %v", out.ForwardTypes, warnings)
+	}
+}
+
+func TestRetry_RetryIf(t *testing.T) {
+
+	errTransient := errors.New("transient")
+	errPermanent := errors.New("permanent")
+
+	var calls int
+	failWith := func(err error) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			calls++
+			return nil, fmt.Errorf("wrapped: %w", err)
+		}
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	retry := Retry{
+		NumRetries: 2,
+		BaseWait:   time.Nanosecond,
+		RetryIf:    func(err error) bool { return errors.Is(err, errTransient) },
+	}
+
+	_, err := NewWithRetries[int](context.Background(), retry).Then(failWith(errTransient)).Finally(f)
+	if calls != 3 || !errors.Is(err, ErrExceededRetries) || !errors.Is(err, errTransient) {
+		t.Fatalf("expected transient error to be retried, got: %d, %v", calls, err)
+	}
+
+	calls = 0
+	_, err = NewWithRetries[int](context.Background(), retry).Then(failWith(errPermanent)).Finally(f)
+	if calls != 1 || !errors.Is(err, errPermanent) || errors.Is(err, ErrExceededRetries) {
+		t.Fatalf("expected permanent error to be forwarded, got: %d, %v", calls, err)
+	}
+
+	// RetryIf overrides Forward
+	retry.Forward = []error{errTransient}
+
+	calls = 0
+	_, err = NewWithRetries[int](context.Background(), retry).Then(failWith(errTransient)).Finally(f)
+	if calls != 3 || !errors.Is(err, ErrExceededRetries) {
+		t.Fatalf("expected RetryIf to take precedence over Forward, got: %d, %v", calls, err)
+	}
+}
+
+func TestRetry_deadline(t *testing.T) {
+
+	errFailed := errors.New("failed")
+
+	var calls int
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		return nil, errFailed
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	retry := Retry{NumRetries: 3, BaseWait: time.Second, Strategy: ConstantBackoff{}}
+
+	start := time.Now()
+	_, err := NewWithRetries[int](ctx, retry).Then(fail).Finally(f)
+
+	if d := time.Since(start); d > 40*time.Millisecond {
+		t.Fatalf("expected no wait beyond the deadline, took: %v", d)
+	}
+	if calls != 1 || !errors.Is(err, errFailed) || !errors.Is(err, ErrExceededRetries) || errors.Is(err, ErrContextDone) {
+		t.Fatalf("expected underlying error without further attempts, got: %d, %v", calls, err)
+	}
+}
+
+func TestRetry_AttemptTimeout(t *testing.T) {
+
+	var calls int
+	hang := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		if calls == 1 {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return []any{calls}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	retry := Retry{
+		NumRetries:     2,
+		BaseWait:       time.Millisecond,
+		AttemptTimeout: 20 * time.Millisecond,
+		Forward:        []error{context.DeadlineExceeded},
+	}
+
+	v, err := NewWithRetries[int](context.Background(), retry).Then(hang).Finally(f)
+	if err != nil || v != 2 {
+		t.Fatalf("expected timed out attempt to be retried, got: %d, %v", v, err)
+	}
+}
+
+func TestRetry_AttemptTimeout_1(t *testing.T) {
+
+	var calls int
+	hang := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	retry := Retry{NumRetries: 3, BaseWait: time.Millisecond, AttemptTimeout: time.Second, Forward: []error{context.DeadlineExceeded}}
+
+	_, err := NewWithRetries[int](ctx, retry).Then(hang).Finally(f)
+	if calls != 1 || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected no retry once the chain's context is done, got: %d, %v", calls, err)
+	}
+}
+
+type notFoundError struct {
+	step string
+}
+
+func (e *notFoundError) Error() string {
+	return e.step + ": not found"
+}
+
+func TestConfig_ErrorMapper(t *testing.T) {
+
+	errMissing := errors.New("missing")
+
+	lookup := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, fmt.Errorf("lookup %v: %w", args[0], errMissing)
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 1, nil
+	}
+
+	cfg := Config{
+		ErrorMapper: func(stepName string, err error) error {
+			if errors.Is(err, errMissing) {
+				return &notFoundError{step: stepName}
+			}
+			return err
+		},
+	}
+
+	_, err := NewWithConfig[int](context.Background(), cfg, "key").Then(lookup).Finally(f)
+
+	var nf *notFoundError
+	if !errors.As(err, &nf) || nf.step != runtimeFuncName(lookup) || errors.Is(err, errMissing) {
+		t.Fatalf("expected mapped error, got: %v", err)
+	}
+
+	// Errors not mapped are returned as is, without identifying the step
+	errFailed := errors.New("failed")
+	fail := func(ctx context.Context, args ...any) (int, error) {
+		return 0, errFailed
+	}
+
+	if _, err := NewWithConfig[int](context.Background(), cfg).Finally(fail); err != errFailed {
+		t.Fatalf("expected unwrapped error, got: %v", err)
+	}
+}
+
+func TestConfig_ErrorMapper_1(t *testing.T) {
+
+	errOptional := errors.New("optional")
+
+	enrich := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errOptional
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int) * 2, nil
+	}
+
+	cfg := Config{
+		ErrorMapper: func(stepName string, err error) error {
+			if errors.Is(err, errOptional) {
+				return nil
+			}
+			return err
+		},
+	}
+
+	v, err := NewWithConfig[int](context.Background(), cfg, 21).Then(enrich).Finally(f)
+	if err != nil || v != 42 {
+		t.Fatalf("expected chain to continue with unchanged args, got: %d, %v", v, err)
+	}
+
+	optional := func(ctx context.Context, args ...any) (int, error) {
+		return 0, errOptional
+	}
+
+	if v, err := NewWithConfig[int](context.Background(), cfg, 21).Finally(optional); err != nil || v != 0 {
+		t.Fatalf("expected swallowed error in Finally, got: %d, %v", v, err)
+	}
+}
+
+func TestChain_Fork(t *testing.T) {
+
+	var loads atomic.Int32
+	load := func(ctx context.Context, args ...any) ([]any, error) {
+		loads.Add(1)
+		return []any{1, 2, 3}, nil
+	}
+
+	// Each step modifies its args in place, which must not affect the other fork
+	scale := func(n int) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			for i := range args {
+				args[i] = args[i].(int) * n
+			}
+			return args, nil
+		}
+	}
+
+	sum := func(ctx context.Context, args ...any) (int, error) {
+		var total int
+		for _, arg := range args {
+			total += arg.(int)
+		}
+		return total, nil
+	}
+
+	base := New[int](context.Background()).Then(load)
+
+	results := make([]int, 2)
+	errs := make([]error, 2)
+
+	var wg sync.WaitGroup
+	for i, n := range []int{10, 100} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = base.Then(scale(n)).Then(scale(n)).Finally(sum)
+		}()
+	}
+	wg.Wait()
+
+	if errs[0] != nil || errs[1] != nil || results[0] != 600 || results[1] != 60000 {
+		t.Fatalf("expected independent forks, got: %v, %v", results, errs)
+	}
+	if loads.Load() != 1 {
+		t.Fatalf("expected shared step to run once, got: %d", loads.Load())
+	}
+
+	if args, err := base.Args(); err != nil || !slices.Equal(args, []any{1, 2, 3}) {
+		t.Fatalf("expected base chain to be unchanged, got: %v, %v", args, err)
+	}
+}
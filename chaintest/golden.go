@@ -0,0 +1,121 @@
+// Package chaintest provides helpers for testing chains, kept separate from the chain
+// package so that it does not depend upon the testing package.
+package chaintest
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Golden compares the JSON serialisation of got against the contents of the golden file at
+// path, failing the test if they differ.  If update is true then the golden file is
+// (re)written instead, which is typically driven by a flag in the calling test package:
+//
+//	var update = flag.Bool("update", false, "update golden files")
+//
+// Serialisation is stable: map keys are sorted, and errors (including the Err of a
+// chain.Result) are recorded by their message, so that outputs of []any and Result values
+// can be compared directly.
+func Golden(t testing.TB, update bool, got any, path string) {
+	t.Helper()
+
+	b, err := json.MarshalIndent(normalise(reflect.ValueOf(got)), "", "  ")
+	if err != nil {
+		t.Fatalf("unable to marshal output: %v", err)
+		return
+	}
+	b = append(b, '\n')
+
+	if update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("unable to create golden file directory: %v", err)
+			return
+		}
+		if err := os.WriteFile(path, b, 0o644); err != nil {
+			t.Fatalf("unable to update golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read golden file (run with -update to create): %v", err)
+		return
+	}
+
+	if !bytes.Equal(b, want) {
+		t.Errorf("output does not match golden file %s\ngot:\n%s\nwant:\n%s", path, b, want)
+	}
+}
+
+var errorType = reflect.TypeFor[error]()
+
+// normalise converts v into a form whose JSON serialisation is stable, replacing errors
+// with their messages.  Structs are converted to maps of their exported fields, which
+// encoding/json then serialises in sorted key order.
+func normalise(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Type().Implements(errorType) && (v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface || !v.IsNil()) {
+		return v.Interface().(error).Error()
+	}
+
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return normalise(v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		fallthrough
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+		s := make([]any, v.Len())
+		for i := range s {
+			s[i] = normalise(v.Index(i))
+		}
+		return s
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		m := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			k, err := json.Marshal(normalise(iter.Key()))
+			if err != nil {
+				return v.Interface()
+			}
+			key := string(k)
+			if iter.Key().Kind() == reflect.String {
+				key = iter.Key().String()
+			}
+			m[key] = normalise(iter.Value())
+		}
+		return m
+	case reflect.Struct:
+		if _, ok := v.Interface().(json.Marshaler); ok {
+			return v.Interface()
+		}
+		m := map[string]any{}
+		for i := range v.NumField() {
+			if f := v.Type().Field(i); f.IsExported() {
+				m[f.Name] = normalise(v.Field(i))
+			}
+		}
+		return m
+	default:
+		return v.Interface()
+	}
+}
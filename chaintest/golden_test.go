@@ -0,0 +1,89 @@
+package chaintest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gford1000-go/chain"
+)
+
+// recorder captures failures so that Golden's mismatch handling can be tested
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) { r.failed = true }
+
+func (r *recorder) Fatalf(format string, args ...any) { r.failed = true }
+
+func TestGolden(t *testing.T) {
+
+	f := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0], map[string]int{"b": 2, "a": 1, "c": 3}}, nil
+	}
+
+	final := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	got, err := chain.New[[]any](context.Background(), "x").Then(f).Finally(final)
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "testdata", "pipeline.golden")
+
+	Golden(t, true, got, path)
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("golden file not written: %v", err)
+	}
+
+	want := "[\n  \"x\",\n  {\n    \"a\": 1,\n    \"b\": 2,\n    \"c\": 3\n  }\n]\n"
+	if string(b) != want {
+		t.Fatalf("unexpected serialisation, got:\n%s", b)
+	}
+
+	Golden(t, false, got, path)
+
+	r := &recorder{TB: t}
+	Golden(r, false, []any{"y"}, path)
+	if !r.failed {
+		t.Fatal("expected mismatch to fail the test")
+	}
+
+	r = &recorder{TB: t}
+	Golden(r, false, got, filepath.Join(t.TempDir(), "missing.golden"))
+	if !r.failed {
+		t.Fatal("expected missing golden file to fail the test")
+	}
+}
+
+func TestGolden_1(t *testing.T) {
+
+	got := []chain.Result[int]{
+		{Value: 1},
+		{Err: errors.New("failed")},
+	}
+
+	path := filepath.Join(t.TempDir(), "results.golden")
+
+	Golden(t, true, got, path)
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("golden file not written: %v", err)
+	}
+
+	want := "[\n  {\n    \"Err\": null,\n    \"Value\": 1\n  },\n  {\n    \"Err\": \"failed\",\n    \"Value\": 0\n  }\n]\n"
+	if string(b) != want {
+		t.Fatalf("unexpected serialisation, got:\n%s", b)
+	}
+}
@@ -0,0 +1,109 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrCheckpointFailed is raised if a Checkpointer fails to save or load a checkpoint
+var ErrCheckpointFailed = errors.New("checkpoint failed")
+
+// Checkpointer persists the progress of a chain, so that a chain interrupted by a crash
+// can be resumed from its last completed step rather than from the start.  Save is called
+// once each step completes, with its zero-based index and the args it returned.  Load
+// returns the index and args of the last step saved, or an index of -1 if there is none.
+//
+// As the args must survive the process, implementations will typically serialise them,
+// for example with encoding/gob, and so the args of a checkpointed chain must be
+// serialisable by the chosen encoding, with any concrete types held as interface values
+// registered via gob.Register.
+type Checkpointer interface {
+	Save(stepIndex int, args []any) error
+	Load() (int, []any, error)
+}
+
+// NewWithCheckpointer starts a new pipeline whose progress is saved to cp.  Should cp hold
+// a checkpoint then the steps up to and including the one saved are skipped, with the
+// chain resuming from the next step using the args saved.  The steps of the resumed chain
+// must match those of the chain that was interrupted, and only args are saved, and so steps
+// that select the steps that follow them at runtime, such as ThenDynamic and Branch, or
+// that replace the chain's context, such as ThenCtx and ThenLabeled, cannot be
+// checkpointed; a chain holding them fails with ErrCheckpointFailed before any step is
+// executed, which is also reported by DryRun.  Compensations of skipped steps are not
+// registered, and so are not run should the resumed chain fail.
+func NewWithCheckpointer[T any](ctx context.Context, cp Checkpointer, args ...any) Chain[T] {
+	return NewWithConfig[T](ctx, Config{Checkpointer: cp}, args...)
+}
+
+// Reasons that a step cannot be restored from a checkpoint, see checkpointable
+const (
+	selectsSteps    = "selects the steps that follow it at runtime"
+	replacesContext = "replaces the chain's context"
+)
+
+// checkpoint holds the checkpoint loaded when the chain executes its first step
+type checkpoint struct {
+	once  sync.Once
+	index int
+	args  []any
+	err   error
+}
+
+// restore returns the chain having skipped the next step, and true, if the step was
+// completed prior to the checkpoint
+func (c Chain[T]) restore() (Chain[T], bool) {
+	if c.cfg.Checkpointer == nil || c.resume == nil {
+		return c, false
+	}
+
+	cp := c.resume
+	cp.once.Do(func() {
+		cp.index, cp.args, cp.err = c.cfg.Checkpointer.Load()
+	})
+	if cp.err != nil {
+		return c.fail(fmt.Errorf("unable to load checkpoint, %w: %w", ErrCheckpointFailed, cp.err)), true
+	}
+	if c.step > cp.index {
+		return c, false
+	}
+
+	next := c
+	if c.step == cp.index {
+		next.args = cp.args
+	}
+	next.step++
+	return next, true
+}
+
+// save saves the completion of the step prior to next, if the chain has a Checkpointer
+func (c Chain[T]) save(next Chain[T]) Chain[T] {
+	if c.cfg.Checkpointer == nil {
+		return next
+	}
+	if err := c.cfg.Checkpointer.Save(c.step, next.args); err != nil {
+		return next.fail(fmt.Errorf("unable to save checkpoint for step %d, %w: %w", c.step, ErrCheckpointFailed, err))
+	}
+	return next
+}
+
+// checkpointable returns an error if the chain has a Checkpointer and any of nodes, which
+// are in reverse order, cannot be restored when the chain is resumed, such as a step that
+// selects the steps that follow it at runtime, or one that replaces the chain's context
+func (c Chain[T]) checkpointable(nodes []*node[T]) error {
+	if c.cfg.Checkpointer == nil || c.err != nil {
+		return nil
+	}
+	step := c.step
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if reason := nodes[i].uncheckpointable; reason != "" {
+			return fmt.Errorf("step %d (%s) %s, %w: not supported",
+				step, c.cfg.nameOf(nodes[i].named), reason, ErrCheckpointFailed)
+		}
+		if !nodes[i].nonStep {
+			step++
+		}
+	}
+	return nil
+}
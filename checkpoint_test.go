@@ -0,0 +1,223 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// gobCheckpointer holds checkpoints gob encoded, as if persisted
+type gobCheckpointer struct {
+	index int
+	data  []byte
+	saves []int
+}
+
+func newGobCheckpointer() *gobCheckpointer {
+	return &gobCheckpointer{index: -1}
+}
+
+func (g *gobCheckpointer) Save(stepIndex int, args []any) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(args); err != nil {
+		return err
+	}
+	g.index, g.data = stepIndex, buf.Bytes()
+	g.saves = append(g.saves, stepIndex)
+	return nil
+}
+
+func (g *gobCheckpointer) Load() (int, []any, error) {
+	if g.index < 0 {
+		return -1, nil, nil
+	}
+	var args []any
+	if err := gob.NewDecoder(bytes.NewReader(g.data)).Decode(&args); err != nil {
+		return 0, nil, err
+	}
+	return g.index, args, nil
+}
+
+func TestNewWithCheckpointer(t *testing.T) {
+
+	var calls []string
+	step := func(name string) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			calls = append(calls, name)
+			return []any{args[0].(int) + 1}, nil
+		}
+	}
+
+	crashed := true
+	errCrash := errors.New("crash")
+	third := func(ctx context.Context, args ...any) ([]any, error) {
+		calls = append(calls, "third")
+		if crashed {
+			return nil, errCrash
+		}
+		return []any{args[0].(int) * 10}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	cp := newGobCheckpointer()
+
+	run := func() (int, error) {
+		return NewWithCheckpointer[int](context.Background(), cp, 0).
+			Then(step("first")).
+			Then(step("second")).
+			Then(third).
+			Finally(f)
+	}
+
+	if _, err := run(); !errors.Is(err, errCrash) {
+		t.Fatalf("expected crash, got: %v", err)
+	}
+	if fmt.Sprint(calls) != "[first second third]" || fmt.Sprint(cp.saves) != "[0 1]" {
+		t.Fatalf("expected progress to be saved after each completed step, got: %v, %v", calls, cp.saves)
+	}
+
+	// Resumes from the checkpoint, with the first two steps skipped
+	calls = nil
+	crashed = false
+
+	n, err := run()
+	if err != nil || n != 20 {
+		t.Fatalf("expected resumed chain to complete, got: %d, %v", n, err)
+	}
+	if fmt.Sprint(calls) != "[third]" {
+		t.Fatalf("expected completed steps not to re-run, got: %v", calls)
+	}
+}
+
+type failingCheckpointer struct {
+	saveErr, loadErr error
+}
+
+func (f failingCheckpointer) Save(int, []any) error { return f.saveErr }
+
+func (f failingCheckpointer) Load() (int, []any, error) { return -1, nil, f.loadErr }
+
+func TestNewWithCheckpointer_1(t *testing.T) {
+
+	errStore := errors.New("store unavailable")
+
+	var calls int
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := NewWithCheckpointer[int](context.Background(), failingCheckpointer{loadErr: errStore}).Then(step).Finally(f)
+	if !errors.Is(err, ErrCheckpointFailed) || !errors.Is(err, errStore) || calls != 0 {
+		t.Fatalf("expected load failure before any step, got: %v, %d", err, calls)
+	}
+
+	_, err = NewWithCheckpointer[int](context.Background(), failingCheckpointer{saveErr: errStore}).Then(step).Then(step).Finally(f)
+	if !errors.Is(err, ErrCheckpointFailed) || !errors.Is(err, errStore) || calls != 1 {
+		t.Fatalf("expected save failure to fail the chain, got: %v, %d", err, calls)
+	}
+}
+
+func TestNewWithCheckpointer_2(t *testing.T) {
+
+	var calls int
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		return args, nil
+	}
+
+	dynamic := func(ctx context.Context, args ...any) ([]Func, []any, error) {
+		calls++
+		return []Func{step}, args, nil
+	}
+
+	pred := func(args ...any) bool {
+		calls++
+		return true
+	}
+
+	enrich := func(ctx context.Context, args ...any) (context.Context, []any, error) {
+		calls++
+		return ctx, args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	chains := map[string]Chain[int]{
+		"dynamic": NewWithCheckpointer[int](context.Background(), newGobCheckpointer(), 1).Then(step).ThenDynamic(dynamic),
+		"branch":  NewWithCheckpointer[int](context.Background(), newGobCheckpointer(), 1).Then(step).Branch(pred, []Func{step}, nil),
+		"ctx":     NewWithCheckpointer[int](context.Background(), newGobCheckpointer(), 1).Then(step).ThenCtx(enrich),
+		"labeled": NewWithCheckpointer[int](context.Background(), newGobCheckpointer(), 1).Then(step).ThenLabeled(step, "a"),
+	}
+
+	for name, c := range chains {
+		calls = 0
+
+		if err := c.DryRun(f); !errors.Is(err, ErrCheckpointFailed) {
+			t.Fatalf("%s: expected DryRun to report the step, got: %v", name, err)
+		}
+		if _, err := c.Finally(f); !errors.Is(err, ErrCheckpointFailed) || calls != 0 {
+			t.Fatalf("%s: expected failure before any step runs, got: %v after %d calls", name, err, calls)
+		}
+	}
+
+	// Without a Checkpointer the steps are supported
+	if v, err := New[int](context.Background(), 1).ThenDynamic(dynamic).Finally(f); err != nil || v != 1 {
+		t.Fatalf("unexpected result, got: %d, %v", v, err)
+	}
+}
+
+func TestNewWithCheckpointer_3(t *testing.T) {
+
+	var undone []int
+	undo := func(ctx context.Context, args ...any) error {
+		undone = append(undone, args[0].(int))
+		return nil
+	}
+
+	inc := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0].(int) + 1}, nil
+	}
+
+	errCrash := errors.New("crash")
+	crash := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errCrash
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	cp := newGobCheckpointer()
+
+	run := func(last Func) error {
+		_, err := NewWithCheckpointer[int](context.Background(), cp, 0).
+			ThenWithCompensation(inc, undo).
+			ThenWithCompensation(inc, undo).
+			Then(last).
+			Finally(f)
+		return err
+	}
+
+	if err := run(crash); !errors.Is(err, errCrash) || fmt.Sprint(undone) != "[2 1]" {
+		t.Fatalf("expected both steps to be compensated, got: %v, %v", undone, err)
+	}
+
+	// On resumption the compensated steps are skipped, so are not compensated again
+	undone = nil
+	if err := run(crash); !errors.Is(err, errCrash) || len(undone) != 0 {
+		t.Fatalf("expected no compensation of restored steps, got: %v, %v", undone, err)
+	}
+}
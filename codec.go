@@ -0,0 +1,103 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ArgCodec serialises args, so that a chain can be suspended in one process and resumed in
+// another.  Args must be compatible with the codec used: for example GobCodec requires that
+// the concrete types of args are registered via gob.Register, and JSONCodec decodes
+// args as the generic JSON types (float64, string, bool, []any, map[string]any).
+type ArgCodec interface {
+	Encode(args []any) ([]byte, error)
+	Decode(data []byte) ([]any, error)
+}
+
+// ErrNilCodec is raised if a nil ArgCodec is provided
+var ErrNilCodec = errors.New("codec cannot be nil")
+
+// EncodeArgs returns the chain's current args serialised by codec.  If the chain is in
+// error then its error is returned.
+func (c Chain[T]) EncodeArgs(codec ArgCodec) ([]byte, error) {
+	c = c.resolve()
+	if c.err != nil {
+		return nil, c.err
+	}
+	if codec == nil {
+		return nil, ErrNilCodec
+	}
+
+	data, err := codec.Encode(c.args)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode args: %w", err)
+	}
+	return data, nil
+}
+
+// NewFromEncoded starts a new pipeline with initial input values decoded by codec from
+// data, typically as created by EncodeArgs
+func NewFromEncoded[T any](ctx context.Context, codec ArgCodec, data []byte) Chain[T] {
+	return NewFromEncodedWithConfig[T](ctx, Config{}, codec, data)
+}
+
+// NewFromEncodedWithConfig starts a new pipeline using the configured options, with initial
+// input values decoded by codec from data
+func NewFromEncodedWithConfig[T any](ctx context.Context, cfg Config, codec ArgCodec, data []byte) Chain[T] {
+	c := NewWithConfig[T](ctx, cfg)
+	if codec == nil {
+		return c.fail(ErrNilCodec)
+	}
+
+	args, err := codec.Decode(data)
+	if err != nil {
+		return c.fail(fmt.Errorf("unable to decode args: %w", err))
+	}
+
+	c.args = args
+	return c
+}
+
+// GobCodec is an ArgCodec using encoding/gob, which preserves the concrete types of args
+// provided that they have been registered via gob.Register
+type GobCodec struct{}
+
+// Encode serialises args using encoding/gob
+func (GobCodec) Encode(args []any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(args); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode deserialises args using encoding/gob
+func (GobCodec) Decode(data []byte) ([]any, error) {
+	var args []any
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&args); err != nil {
+		return nil, err
+	}
+	return args, nil
+}
+
+// JSONCodec is an ArgCodec using encoding/json, which is portable but does not preserve
+// the concrete types of args
+type JSONCodec struct{}
+
+// Encode serialises args as a JSON array
+func (JSONCodec) Encode(args []any) ([]byte, error) {
+	return json.Marshal(args)
+}
+
+// Decode deserialises args from a JSON array
+func (JSONCodec) Decode(data []byte) ([]any, error) {
+	var args []any
+	if err := json.Unmarshal(data, &args); err != nil {
+		return nil, err
+	}
+	return args, nil
+}
@@ -0,0 +1,87 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleNewFromEncoded() {
+
+	double := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0].(int) * 2, args[1]}, nil
+	}
+
+	// Suspend the chain after the first step ...
+	data, _ := New[string](context.Background(), 21, "answer").
+		Then(double).
+		EncodeArgs(GobCodec{})
+
+	// ... and resume it elsewhere
+	f := func(ctx context.Context, args ...any) (string, error) {
+		return fmt.Sprintf("%v: %v", args[1], args[0]), nil
+	}
+
+	result, _ := NewFromEncoded[string](context.Background(), GobCodec{}, data).
+		Finally(f)
+
+	fmt.Println(result)
+	// Output: answer: 42
+}
+
+func TestNewFromEncoded(t *testing.T) {
+
+	data, err := New[int](context.Background(), 1, "a", true).EncodeArgs(JSONCodec{})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if string(data) != `[1,"a",true]` {
+		t.Fatalf("unexpected encoding, got: %s", data)
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		return fmt.Sprintf("%T %T %T", args...), nil
+	}
+
+	result, err := NewFromEncoded[string](context.Background(), JSONCodec{}, data).Finally(f)
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if result != "float64 string bool" {
+		t.Fatalf("unexpected decoded types, got: %v", result)
+	}
+}
+
+func TestNewFromEncoded_1(t *testing.T) {
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := NewFromEncoded[int](context.Background(), JSONCodec{}, []byte("{")).Finally(f)
+	if err == nil {
+		t.Fatal("expected decode error, got nil")
+	}
+
+	_, err = NewFromEncoded[int](context.Background(), nil, nil).Finally(f)
+	if !errors.Is(err, ErrNilCodec) {
+		t.Fatalf("expected nil codec error, got: %v", err)
+	}
+
+	errFailed := errors.New("failed")
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errFailed
+	}
+
+	_, err = New[int](context.Background()).Then(fail).EncodeArgs(GobCodec{})
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("expected chain error, got: %v", err)
+	}
+
+	type unregistered struct{ X int }
+	_, err = New[int](context.Background(), unregistered{1}).EncodeArgs(GobCodec{})
+	if err == nil {
+		t.Fatal("expected encode error for unregistered type, got nil")
+	}
+}
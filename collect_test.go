@@ -0,0 +1,90 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNewCollecting(t *testing.T) {
+
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+
+	fail := func(err error) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			return nil, err
+		}
+	}
+
+	var seen []any
+	inc := func(ctx context.Context, args ...any) ([]any, error) {
+		seen = append(seen, args[0])
+		return []any{args[0].(int) + 1}, nil
+	}
+
+	var finalised bool
+	f := func(ctx context.Context, args ...any) (int, error) {
+		finalised = true
+		return args[0].(int), nil
+	}
+
+	_, err := NewCollecting[int](context.Background(), 0).
+		Then(inc).
+		Then(fail(errFirst)).
+		Then(inc).
+		Then(fail(errSecond)).
+		Then(inc).
+		Finally(f)
+
+	if !errors.Is(err, errFirst) || !errors.Is(err, errSecond) {
+		t.Fatalf("expected both errors to be joined, got: %v", err)
+	}
+	if len(seen) != 3 || seen[0] != 0 || seen[1] != 1 || seen[2] != 2 {
+		t.Fatalf("expected failing steps to pass on prior args, got: %v", seen)
+	}
+	if finalised {
+		t.Fatal("expected final func not to be invoked")
+	}
+
+	result, err := NewCollecting[int](context.Background(), 0).
+		Then(inc).
+		Finally(f)
+
+	if err != nil || result != 1 {
+		t.Fatalf("unexpected result, got: %v, %v", result, err)
+	}
+
+	// The default remains fail-fast
+	seen = nil
+	_, err = New[int](context.Background(), 0).
+		Then(fail(errFirst)).
+		Then(inc).
+		Finally(f)
+
+	if !errors.Is(err, errFirst) || len(seen) != 0 {
+		t.Fatalf("expected chain to abort, got: %v, %v", err, seen)
+	}
+}
+
+func TestNewCollecting_1(t *testing.T) {
+
+	errFailed := errors.New("failed")
+
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errFailed
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := NewCollecting[int](context.Background()).
+		Then(fail).
+		Then(nil).
+		Finally(f)
+
+	if !errors.Is(err, errFailed) || !errors.Is(err, ErrNilThenFunc) {
+		t.Fatalf("expected collected error to be joined with fatal error, got: %v", err)
+	}
+}
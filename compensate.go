@@ -0,0 +1,96 @@
+package chain
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrCompensationFailed is raised if a compensation returns an error or panics whilst
+// rolling back a failed chain
+var ErrCompensationFailed = errors.New("compensation failed")
+
+// Compensation is the type of func that can be passed to Chain.ThenWithCompensation,
+// to undo the effects of its step should the chain subsequently fail
+type Compensation func(context.Context, ...any) error
+
+type compensation struct {
+	name   string
+	undo   Compensation
+	args   []any
+	onlyOn ErrorSet
+}
+
+// ErrNilCompensation is raised if a nil compensation is passed to ThenWithCompensation
+var ErrNilCompensation = errors.New("compensation provided to ThenWithCompensation cannot be nil")
+
+// ThenWithCompensation adds a transformation step together with the compensation that
+// will undo it, should the chain fail at any later point (including in Finally).  The
+// compensation is passed the args that f returned.  Compensations are run in the reverse
+// order to their steps.
+//
+// If onlyOn is provided, the compensation is run only if the error that failed the chain
+// matches one of its errors, tested via errors.Is().  Otherwise it is always run.
+//
+// Compensations run under a context detached from the chain's context, with a timeout of
+// Config.CompensationTimeout, so that rollback completes even when the failure was caused
+// by the chain's context being cancelled or reaching its deadline.  Values held by the
+// chain's context remain available to the compensation.
+func (c Chain[T]) ThenWithCompensation(f Func, undo Compensation, onlyOn ...error) Chain[T] {
+	return c.queue(f, cmp.Or(check(ErrNilThenFunc, f), check(ErrNilCompensation, undo)), func(c Chain[T]) Chain[T] {
+		if c.err == nil && f != nil && undo == nil {
+			return c.fail(ErrNilCompensation)
+		}
+
+		// Only a step that succeeded has effects to undo, so not one that failed but whose
+		// error was collected or mapped away, nor one restored from a checkpoint
+		next, succeeded := c.thenSucceeded(f, f, c.cfg.Retry)
+		if next.err != nil || !succeeded {
+			return next
+		}
+
+		// Clipped so that chains sharing earlier steps do not share compensations
+		next.compensations = append(slices.Clip(next.compensations),
+			compensation{name: c.cfg.funcName(f), undo: undo, args: next.args, onlyOn: NewErrorSet(onlyOn...)})
+		return next
+	})
+}
+
+// compensate runs any compensations in reverse order, returning err together with any
+// errors arising from the compensations
+func (c Chain[T]) compensate(err error) error {
+	if len(c.compensations) == 0 {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c.ctx), c.cfg.CompensationTimeout)
+	defer cancel()
+
+	errs := []error{err}
+	for i := len(c.compensations) - 1; i >= 0; i-- {
+		comp := c.compensations[i]
+		if len(comp.onlyOn) > 0 && !comp.onlyOn.Contains(err) {
+			continue
+		}
+		if cerr := compensateWrap(ctx, c.cfg.PanicMapper, comp); cerr != nil {
+			errs = append(errs, fmt.Errorf("compensation for %s: %w: %w", comp.name, ErrCompensationFailed, cerr))
+		}
+	}
+
+	if len(errs) == 1 {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+func compensateWrap(ctx context.Context, panics PanicMapper, comp compensation) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panics.toError(comp.name, r)
+		}
+	}()
+
+	return comp.undo(ctx, comp.args...)
+}
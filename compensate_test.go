@@ -0,0 +1,243 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func ExampleChain_ThenWithCompensation() {
+
+	reserve := func(ctx context.Context, args ...any) ([]any, error) {
+		fmt.Println("reserved", args[0])
+		return args, nil
+	}
+
+	release := func(ctx context.Context, args ...any) error {
+		fmt.Println("released", args[0])
+		return nil
+	}
+
+	charge := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errors.New("card declined")
+	}
+
+	done := func(ctx context.Context, args ...any) (bool, error) {
+		return true, nil
+	}
+
+	_, err := New[bool](context.Background(), "seat 1A").
+		ThenWithCompensation(reserve, release).
+		Then(charge).
+		Finally(done)
+
+	fmt.Println("Failed:", err != nil)
+	// Output:
+	// reserved seat 1A
+	// released seat 1A
+	// Failed: true
+}
+
+func TestChain_ThenWithCompensation(t *testing.T) {
+
+	var undone []any
+
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0].(int) + 1}, nil
+	}
+
+	undo := func(ctx context.Context, args ...any) error {
+		undone = append(undone, args[0])
+		return nil
+	}
+
+	errFailed := errors.New("failed")
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, errFailed
+	}
+
+	_, err := New[int](context.Background(), 0).
+		ThenWithCompensation(step, undo).
+		Then(step).
+		ThenWithCompensation(step, undo).
+		Finally(f)
+
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("expected underlying error, got: %v", err)
+	}
+	if fmt.Sprint(undone) != "[3 1]" {
+		t.Fatalf("expected compensations in reverse order, got: %v", undone)
+	}
+
+	undone = nil
+	_, err = New[int](context.Background(), 0).
+		ThenWithCompensation(step, undo).
+		Finally(func(ctx context.Context, args ...any) (int, error) { return 1, nil })
+
+	if err != nil || undone != nil {
+		t.Fatalf("expected no compensation on success, got: %v, %v", err, undone)
+	}
+
+	_, err = New[int](context.Background(), 0).
+		ThenWithCompensation(step, nil).
+		Finally(f)
+
+	if !errors.Is(err, ErrNilCompensation) {
+		t.Fatalf("expected nil compensation error, got: %v", err)
+	}
+}
+
+func TestChain_ThenWithCompensation_1(t *testing.T) {
+
+	type key struct{}
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "v"))
+
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		cancel()
+		return args, nil
+	}
+
+	var undoErr error
+	var undoValue any
+	undo := func(ctx context.Context, args ...any) error {
+		undoErr = ctx.Err()
+		undoValue = ctx.Value(key{})
+		return nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := New[int](ctx, 0).
+		ThenWithCompensation(step, undo).
+		Then(step).
+		Finally(f)
+
+	if !errors.Is(err, ErrContextDone) {
+		t.Fatalf("expected context done error, got: %v", err)
+	}
+	if undoErr != nil {
+		t.Fatalf("expected compensation context to be detached, got: %v", undoErr)
+	}
+	if undoValue != "v" {
+		t.Fatalf("expected compensation context to retain values, got: %v", undoValue)
+	}
+}
+
+func TestChain_ThenWithCompensation_2(t *testing.T) {
+
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	hang := func(ctx context.Context, args ...any) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	errFailed := errors.New("failed")
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, errFailed
+	}
+
+	start := time.Now()
+
+	_, err := NewWithConfig[int](context.Background(), Config{CompensationTimeout: 10 * time.Millisecond}, 0).
+		ThenWithCompensation(step, hang).
+		Finally(f)
+
+	if !errors.Is(err, errFailed) || !errors.Is(err, ErrCompensationFailed) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected step, compensation and deadline errors, got: %v", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Fatalf("compensation timeout not applied, took: %v", d)
+	}
+}
+
+func TestChain_ThenWithCompensation_3(t *testing.T) {
+
+	errBusiness := errors.New("business rule violated")
+	errTransient := errors.New("transient")
+
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	var undone []string
+	undo := func(name string) Compensation {
+		return func(ctx context.Context, args ...any) error {
+			undone = append(undone, name)
+			return nil
+		}
+	}
+
+	run := func(err error) {
+		undone = nil
+		_, _ = New[int](context.Background()).
+			ThenWithCompensation(step, undo("always")).
+			ThenWithCompensation(step, undo("business"), errBusiness).
+			Finally(func(ctx context.Context, args ...any) (int, error) { return 0, err })
+	}
+
+	run(errBusiness)
+	if fmt.Sprint(undone) != "[business always]" {
+		t.Fatalf("expected both compensations, got: %v", undone)
+	}
+
+	run(errTransient)
+	if fmt.Sprint(undone) != "[always]" {
+		t.Fatalf("expected only unconditional compensation, got: %v", undone)
+	}
+}
+
+func TestChain_ThenWithCompensation_4(t *testing.T) {
+
+	errFailed := errors.New("failed")
+
+	var undone int
+	undo := func(ctx context.Context, args ...any) error {
+		undone++
+		return nil
+	}
+
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errFailed
+	}
+
+	final := func(ctx context.Context, args ...any) (int, error) {
+		return 0, errors.New("final failed")
+	}
+
+	// A failed step has nothing to undo, even if its error is collected
+	_, err := NewCollecting[int](context.Background()).
+		ThenWithCompensation(fail, undo).
+		Finally(final)
+
+	if !errors.Is(err, errFailed) || undone != 0 {
+		t.Fatalf("expected no compensation of collected failure, got: %d, %v", undone, err)
+	}
+
+	// Nor if its error is mapped away
+	cfg := Config{
+		ErrorMapper: func(step string, err error) error {
+			if errors.Is(err, errFailed) {
+				return nil
+			}
+			return err
+		},
+	}
+
+	_, err = NewWithConfig[int](context.Background(), cfg).
+		ThenWithCompensation(fail, undo).
+		Finally(final)
+
+	if err == nil || undone != 0 {
+		t.Fatalf("expected no compensation of mapped failure, got: %d, %v", undone, err)
+	}
+}
@@ -0,0 +1,72 @@
+package chain
+
+import (
+	"context"
+	"reflect"
+)
+
+// ThenCtx adds a transformation step that may also enrich the context, for example with a
+// value or deadline, with the context returned by f replacing the chain's context for all
+// subsequent steps.  A nil context leaves the chain's context unchanged.
+//
+// Retry.AttemptTimeout is not applied to f, as the context it returns must outlive the
+// attempt.  Values added to the step's context by the chain itself, such as its trace span,
+// are not visible via the returned context.
+func (c Chain[T]) ThenCtx(f func(context.Context, ...any) (context.Context, []any, error)) Chain[T] {
+	return c.queue(f, check(ErrNilThenFunc, f), func(c Chain[T]) Chain[T] {
+		var next context.Context
+		var g Func
+		if f != nil {
+			g = func(ctx context.Context, args ...any) ([]any, error) {
+				nctx, result, err := f(ctx, args...)
+				if err != nil {
+					return nil, err
+				}
+				if nctx != nil {
+					next = rebasedCtx{Context: nctx, step: ctx, chain: c.ctx}
+				}
+				return result, nil
+			}
+		}
+
+		retry := c.cfg.Retry
+		retry.AttemptTimeout = 0
+
+		out := c.thenWithRetry(g, f, retry)
+		if out.err == nil && next != nil {
+			out.ctx = next
+		}
+		return out
+	}).uncheckpointable(replacesContext)
+}
+
+// rebasedCtx is a context returned by a ThenCtx func, which hides the values that the
+// chain added to the context of the step, so that they do not outlive the step
+type rebasedCtx struct {
+	context.Context
+	step  context.Context
+	chain context.Context
+}
+
+func (r rebasedCtx) Value(key any) any {
+	v := r.Context.Value(key)
+	if s := r.step.Value(key); sameValue(v, s) {
+		if cv := r.chain.Value(key); !sameValue(s, cv) {
+			return cv
+		}
+	}
+	return v
+}
+
+// sameValue returns true if a and b are equal, without panicking for values that are not
+// comparable, which are treated as different
+func sameValue(a, b any) bool {
+	ta, tb := reflect.TypeOf(a), reflect.TypeOf(b)
+	if ta != tb {
+		return false
+	}
+	if ta != nil && !ta.Comparable() {
+		return false
+	}
+	return a == b
+}
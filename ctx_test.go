@@ -0,0 +1,115 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChain_ThenCtx(t *testing.T) {
+
+	type key struct{}
+
+	enrich := func(ctx context.Context, args ...any) (context.Context, []any, error) {
+		return context.WithValue(ctx, key{}, "tenant-1"), args, nil
+	}
+
+	keep := func(ctx context.Context, args ...any) (context.Context, []any, error) {
+		return nil, args, nil
+	}
+
+	var seen []any
+	read := func(ctx context.Context, args ...any) ([]any, error) {
+		seen = append(seen, ctx.Value(key{}))
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		s, _ := ctx.Value(key{}).(string)
+		return s, nil
+	}
+
+	result, err := New[string](context.Background()).
+		Then(read).
+		ThenCtx(enrich).
+		Then(read).
+		ThenCtx(keep).
+		Then(read).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if result != "tenant-1" {
+		t.Fatalf("expected value to reach the final step, got: %v", result)
+	}
+	if len(seen) != 3 || seen[0] != nil || seen[1] != "tenant-1" || seen[2] != "tenant-1" {
+		t.Fatalf("unexpected values seen by later steps, got: %v", seen)
+	}
+
+	errFailed := errors.New("failed")
+	fail := func(ctx context.Context, args ...any) (context.Context, []any, error) {
+		return nil, nil, errFailed
+	}
+
+	_, err = New[string](context.Background()).
+		ThenCtx(fail).
+		Finally(f)
+
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("expected underlying error, got: %v", err)
+	}
+
+	_, err = New[string](context.Background()).
+		ThenCtx(nil).
+		Finally(f)
+
+	if !errors.Is(err, ErrNilThenFunc) {
+		t.Fatalf("expected nil func error, got: %v", err)
+	}
+}
+
+func TestChain_ThenCtx_1(t *testing.T) {
+
+	type key struct{}
+	type spanKey struct{}
+
+	enrich := func(ctx context.Context, args ...any) (context.Context, []any, error) {
+		return context.WithValue(ctx, key{}, "tenant-1"), args, nil
+	}
+
+	var spans []any
+	read := func(ctx context.Context, args ...any) ([]any, error) {
+		spans = append(spans, ctx.Value(spanKey{}))
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		s, _ := ctx.Value(key{}).(string)
+		return s, nil
+	}
+
+	cfg := Config{
+		Retry: Retry{NumRetries: 1, AttemptTimeout: time.Second},
+		Tracer: func(ctx context.Context, step string, index int) (context.Context, func(int, error)) {
+			return context.WithValue(ctx, spanKey{}, index), func(int, error) {}
+		},
+	}
+
+	result, err := NewWithConfig[string](context.Background(), cfg).
+		ThenCtx(enrich).
+		Then(read).
+		Then(read).
+		Finally(f)
+
+	if err != nil || result != "tenant-1" {
+		t.Fatalf("expected later steps to run with the returned context, got: %q, %v", result, err)
+	}
+	if len(spans) != 2 || spans[0] != 1 || spans[1] != 2 {
+		t.Fatalf("expected each step to see only its own span, got: %v", spans)
+	}
+}
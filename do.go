@@ -0,0 +1,21 @@
+package chain
+
+import "context"
+
+// Do adds a step that performs an action for its side effects, such as emitting an event,
+// forwarding the existing args unchanged.  Unlike a transformation step the action returns
+// only an error, which fails the chain.  The chain's retry policy applies to the action.
+func (c Chain[T]) Do(fn func(context.Context, ...any) error) Chain[T] {
+	return c.queue(fn, check(ErrNilThenFunc, fn), func(c Chain[T]) Chain[T] {
+		var g Func
+		if fn != nil {
+			g = func(ctx context.Context, args ...any) ([]any, error) {
+				if err := fn(ctx, args...); err != nil {
+					return nil, err
+				}
+				return args, nil
+			}
+		}
+		return c.then(g, fn)
+	})
+}
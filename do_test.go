@@ -0,0 +1,82 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func ExampleChain_Do() {
+
+	emit := func(ctx context.Context, args ...any) error {
+		fmt.Println("emitted", args[0])
+		return nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int) * 2, nil
+	}
+
+	result, _ := New[int](context.Background(), 5).
+		Do(emit).
+		Finally(f)
+
+	fmt.Println("Result:", result)
+	// Output:
+	// emitted 5
+	// Result: 10
+}
+
+func TestChain_Do(t *testing.T) {
+
+	errUnavailable := errors.New("unavailable")
+
+	calls := 0
+	flaky := func(ctx context.Context, args ...any) error {
+		calls++
+		if calls == 1 {
+			return errUnavailable
+		}
+		return nil
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		return fmt.Sprintf("%v %v", args[0], args[1]), nil
+	}
+
+	result, err := NewWithRetries[string](context.Background(), Retry{NumRetries: 1, BaseWait: time.Millisecond}, "a", "b").
+		Do(flaky).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if result != "a b" {
+		t.Fatalf("expected args to be forwarded unchanged, got: %v", result)
+	}
+	if calls != 2 {
+		t.Fatalf("expected action to be retried, got calls: %d", calls)
+	}
+
+	fail := func(ctx context.Context, args ...any) error {
+		return errUnavailable
+	}
+
+	_, err = New[string](context.Background(), "a").
+		Do(fail).
+		Finally(f)
+
+	if !errors.Is(err, errUnavailable) {
+		t.Fatalf("expected action error, got: %v", err)
+	}
+
+	_, err = New[string](context.Background(), "a").
+		Do(nil).
+		Finally(f)
+
+	if !errors.Is(err, ErrNilThenFunc) {
+		t.Fatalf("expected NilThen error, got: %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package chain
+
+import "context"
+
+// ThenDynamic adds a transformation step whose follow-on steps are determined at runtime.
+// f returns both the new args and the funcs to be invoked next, in sequence, as if each
+// were added by Then, so are subject to the chain's retry policy and context.  As the funcs are
+// only known at runtime, the step cannot be checkpointed; see NewWithCheckpointer.
+func (c Chain[T]) ThenDynamic(f func(context.Context, ...any) ([]Func, []any, error)) Chain[T] {
+	return c.queue(f, check(ErrNilThenFunc, f), func(c Chain[T]) Chain[T] {
+		var fs []Func
+		var g Func
+		if f != nil {
+			g = func(ctx context.Context, args ...any) ([]any, error) {
+				next, result, err := f(ctx, args...)
+				if err != nil {
+					return nil, err
+				}
+				fs = next
+				return result, nil
+			}
+		}
+
+		out := c.then(g, f)
+		for _, f := range fs {
+			out = out.Then(f)
+		}
+		return out
+	}).uncheckpointable(selectsSteps)
+}
@@ -0,0 +1,80 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestChain_ThenDynamic(t *testing.T) {
+
+	inc := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0].(int) + 1}, nil
+	}
+
+	// Generates one increment per unit of input, starting from zero
+	discover := func(ctx context.Context, args ...any) ([]Func, []any, error) {
+		var fs []Func
+		for range args[0].(int) {
+			fs = append(fs, inc)
+		}
+		return fs, []any{0}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	for _, n := range []int{0, 1, 5} {
+		result, err := New[int](context.Background(), n).
+			ThenDynamic(discover).
+			Finally(f)
+
+		if err != nil {
+			t.Fatalf("unexpected error, got: %v", err)
+		}
+		if result != n {
+			t.Fatalf("expected %d generated steps, got: %d", n, result)
+		}
+	}
+}
+
+func TestChain_ThenDynamic_1(t *testing.T) {
+
+	errFailed := errors.New("failed")
+
+	pass := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errFailed
+	}
+
+	var after bool
+	next := func(ctx context.Context, args ...any) ([]any, error) {
+		after = true
+		return args, nil
+	}
+
+	discover := func(ctx context.Context, args ...any) ([]Func, []any, error) {
+		return []Func{pass, fail, pass}, args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := New[int](context.Background()).
+		ThenDynamic(discover).
+		Then(next).
+		Finally(f)
+
+	if !errors.Is(err, errFailed) || after {
+		t.Fatalf("expected generated step failure to abort the chain, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "step 2 (") {
+		t.Fatalf("expected generated steps to be indexed, got: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package chain
+
+import (
+	"context"
+	"maps"
+	"slices"
+)
+
+// Envelope carries data together with metadata (trace IDs, timings etc.), providing a
+// structured alternative to positional args for complex pipelines
+type Envelope struct {
+	Data []any
+	Meta map[string]any
+}
+
+// envelopeOf returns a copy of the envelope held by args, or a new envelope holding args as
+// its Data if args is not a single *Envelope.  Copying ensures that a failed attempt does
+// not affect a retry.
+func envelopeOf(args []any) *Envelope {
+	if len(args) == 1 {
+		if e, ok := args[0].(*Envelope); ok && e != nil {
+			return &Envelope{Data: slices.Clone(e.Data), Meta: maps.Clone(e.Meta)}
+		}
+	}
+	return &Envelope{Data: slices.Clone(args), Meta: map[string]any{}}
+}
+
+// ThenEnvelope adds a transformation step in which f receives the args as an Envelope,
+// which it may modify in place.  If the args are not already an envelope then they become
+// its Data, with empty Meta.  The envelope is passed on as the single arg to the next step,
+// so subsequent steps should also be added via ThenEnvelope, with the output obtained via
+// FinallyEnvelope.
+func (c Chain[T]) ThenEnvelope(f func(ctx context.Context, e *Envelope) error) Chain[T] {
+	return c.queue(f, check(ErrNilThenFunc, f), func(c Chain[T]) Chain[T] {
+		var g Func
+		if f != nil {
+			g = func(ctx context.Context, args ...any) ([]any, error) {
+				e := envelopeOf(args)
+				if e.Meta == nil {
+					e.Meta = map[string]any{}
+				}
+				if err := f(ctx, e); err != nil {
+					return nil, err
+				}
+				return []any{e}, nil
+			}
+		}
+		return c.then(g, f)
+	})
+}
+
+// FinallyEnvelope returns a FinalFunc that provides the args to f as an Envelope, in the
+// same way as ThenEnvelope, so that both the data and metadata can be read
+func FinallyEnvelope[T any](f func(ctx context.Context, e *Envelope) (T, error)) FinalFunc[T] {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, args ...any) (T, error) {
+		return f(ctx, envelopeOf(args))
+	}
+}
@@ -0,0 +1,76 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func ExampleChain_ThenEnvelope() {
+
+	trace := func(ctx context.Context, e *Envelope) error {
+		e.Meta["trace"] = "abc123"
+		return nil
+	}
+
+	double := func(ctx context.Context, e *Envelope) error {
+		e.Data[0] = e.Data[0].(int) * 2
+		return nil
+	}
+
+	f := func(ctx context.Context, e *Envelope) (string, error) {
+		return fmt.Sprintf("%v (trace %v)", e.Data[0], e.Meta["trace"]), nil
+	}
+
+	result, _ := New[string](context.Background(), 21).
+		ThenEnvelope(trace).
+		ThenEnvelope(double).
+		Finally(FinallyEnvelope(f))
+
+	fmt.Println(result)
+	// Output: 42 (trace abc123)
+}
+
+func TestChain_ThenEnvelope(t *testing.T) {
+
+	var attempts int
+	flaky := func(ctx context.Context, e *Envelope) error {
+		attempts++
+		e.Data = append(e.Data, attempts)
+		e.Meta["attempt"] = attempts
+		if attempts < 2 {
+			return errors.New("transient")
+		}
+		return nil
+	}
+
+	f := func(ctx context.Context, e *Envelope) (string, error) {
+		return fmt.Sprint(e.Data, e.Meta["attempt"]), nil
+	}
+
+	result, err := NewWithRetries[string](context.Background(), Retry{NumRetries: 1, BaseWait: time.Millisecond}, 0).
+		ThenEnvelope(func(ctx context.Context, e *Envelope) error { return nil }).
+		ThenEnvelope(flaky).
+		Finally(FinallyEnvelope(f))
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if result != "[0 2] 2" {
+		t.Fatalf("expected failed attempt to leave envelope unchanged, got: %v", result)
+	}
+
+	_, err = New[string](context.Background()).
+		ThenEnvelope(nil).
+		Finally(FinallyEnvelope(f))
+
+	if !errors.Is(err, ErrNilThenFunc) {
+		t.Fatalf("expected nil func error, got: %v", err)
+	}
+
+	if FinallyEnvelope[int](nil) != nil {
+		t.Fatal("expected nil FinalFunc for nil func")
+	}
+}
@@ -0,0 +1,35 @@
+package chain
+
+import "errors"
+
+// ErrorSet is an ordered set of errors, allowing retry classifications such as
+// "never retry these" to be defined once and shared.  As an ErrorSet is a []error it can
+// be assigned directly to Retry.Forward.
+type ErrorSet []error
+
+// NewErrorSet returns an ErrorSet containing the specified errors
+func NewErrorSet(errs ...error) ErrorSet {
+	var s ErrorSet
+	s.Add(errs...)
+	return s
+}
+
+// Add appends the errors to the set, preserving order.  Nil errors and errors that the set
+// already Contains are ignored.
+func (s *ErrorSet) Add(errs ...error) {
+	for _, err := range errs {
+		if err != nil && !s.Contains(err) {
+			*s = append(*s, err)
+		}
+	}
+}
+
+// Contains returns true if any member of the set matches err, using errors.Is()
+func (s ErrorSet) Contains(err error) bool {
+	for _, e := range s {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+	return false
+}
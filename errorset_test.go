@@ -0,0 +1,58 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func ExampleErrorSet() {
+
+	var errPermanent = errors.New("permanent")
+
+	// Shared classification of errors that should never be retried
+	var neverRetry = NewErrorSet(ErrUnhandledPanic, errPermanent)
+
+	var retry = Retry{
+		NumRetries: 3,
+		BaseWait:   2 * time.Millisecond,
+		Forward:    neverRetry,
+	}
+
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errPermanent
+	}
+
+	return99 := func(ctx context.Context, args ...any) (int, error) {
+		return 99, nil
+	}
+
+	_, err := NewWithRetries[int](context.Background(), retry, 5).
+		Then(fail).
+		Finally(return99)
+
+	fmt.Println("Err:", err)
+	// Output: Err: error in step 0 (github.com/gford1000-go/chain.ExampleErrorSet.func1): permanent
+}
+
+func TestErrorSet(t *testing.T) {
+
+	e1 := errors.New("e1")
+	e2 := errors.New("e2")
+
+	s := NewErrorSet(e1, nil, e1)
+	s.Add(e2, fmt.Errorf("wrapped: %w", e1))
+
+	if len(s) != 2 || s[0] != e1 || s[1] != e2 {
+		t.Fatalf("unexpected set contents: %v", s)
+	}
+
+	if !s.Contains(fmt.Errorf("wrapped: %w", e2)) {
+		t.Fatal("expected wrapped error to be contained")
+	}
+	if s.Contains(errors.New("e3")) {
+		t.Fatal("unexpected error contained")
+	}
+}
@@ -0,0 +1,71 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrArgCount is raised when the number of args differs from the number expected
+var ErrArgCount = errors.New("unexpected number of args")
+
+// FinallyExact ends the pipeline as Finally, but first checks that f will receive exactly
+// n args, failing with ErrArgCount if not.  This surfaces steps that accidentally
+// accumulate or drop args, which would otherwise be silently ignored by f.
+func (c Chain[T]) FinallyExact(n int, f FinalFunc[T]) (T, error) {
+	var g FinalFunc[T]
+	if f != nil {
+		g = func(ctx context.Context, args ...any) (T, error) {
+			if len(args) != n {
+				var zero T
+				return zero, fmt.Errorf("expected %d args, got %d: %w", n, len(args), ErrArgCount)
+			}
+			return f(ctx, args...)
+		}
+	}
+	return c.finally(g, f)
+}
+
+// RequireArgs adds a step that checks the chain has exactly n args, forwarding them
+// unchanged if so, and otherwise failing with ErrArgCount.  Placed between steps, it
+// surfaces a mismatch as a descriptive error rather than as a panic in the next step.
+func (c Chain[T]) RequireArgs(n int) Chain[T] {
+	return c.queue("require args", nil, func(c Chain[T]) Chain[T] {
+		require := func(ctx context.Context, args ...any) ([]any, error) {
+			if len(args) != n {
+				return nil, fmt.Errorf("expected %d args, got %d: %w", n, len(args), ErrArgCount)
+			}
+			return args, nil
+		}
+
+		return c.thenWithRetry(require, "require args", Retry{})
+	})
+}
+
+// FinallyWithArgs ends the pipeline as Finally, additionally returning a copy of the args
+// that were passed to f, for diagnostics.  The args are returned even if f fails, but are
+// nil if the chain failed before f was invoked.
+func (c Chain[T]) FinallyWithArgs(f FinalFunc[T]) (T, []any, error) {
+	var consumed []any
+	var g FinalFunc[T]
+	if f != nil {
+		g = func(ctx context.Context, args ...any) (T, error) {
+			consumed = slices.Clone(args)
+			return f(ctx, args...)
+		}
+	}
+
+	result, err := c.finally(g, f)
+	return result, consumed, err
+}
+
+// FinallySafe ends the pipeline as FinallyWithArgs, but always recovers a panic in f as a
+// PanicError, regardless of the chain's PanicMode, so that the args passed to f are
+// available to reproduce the failure.  The args returned are unaffected by any changes f
+// makes to its args slice.
+func (c Chain[T]) FinallySafe(f FinalFunc[T]) (T, []any, error) {
+	c = c.resolve()
+	c.cfg.PanicMode = PanicRecover
+	return c.FinallyWithArgs(f)
+}
@@ -0,0 +1,139 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestChain_FinallyExact(t *testing.T) {
+
+	// Accidentally passes through the input alongside its output
+	leaky := func(ctx context.Context, args ...any) ([]any, error) {
+		return append([]any{args[0].(int) + 1}, args...), nil
+	}
+
+	calls := 0
+	sum := func(ctx context.Context, args ...any) (int, error) {
+		calls++
+		return args[0].(int) + args[1].(int), nil
+	}
+
+	result, err := New[int](context.Background(), 5).
+		Then(leaky).
+		FinallyExact(2, sum)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if result != 11 {
+		t.Fatalf("unexpected result.  wanted: 11, got: %v", result)
+	}
+
+	_, err = New[int](context.Background(), 5).
+		Then(leaky).
+		Then(leaky).
+		FinallyExact(2, sum)
+
+	if !errors.Is(err, ErrArgCount) {
+		t.Fatalf("expected arg count error, got: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected terminal func not to be called, got calls: %d", calls)
+	}
+
+	_, err = New[int](context.Background(), 5).FinallyExact(1, nil)
+	if !errors.Is(err, ErrNilFinalFunc) {
+		t.Fatalf("expected NilFinally error, got: %v", err)
+	}
+}
+
+func TestChain_RequireArgs(t *testing.T) {
+
+	split := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0], args[0]}, nil
+	}
+
+	add := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0].(int) + args[1].(int)}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	n, err := New[int](context.Background(), 2).Then(split).RequireArgs(2).Then(add).Finally(f)
+	if err != nil || n != 4 {
+		t.Fatalf("expected args to be forwarded, got: %d, %v", n, err)
+	}
+
+	_, err = New[int](context.Background(), 2).RequireArgs(2).Then(add).Finally(f)
+	if !errors.Is(err, ErrArgCount) || errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected arg count error, got: %v", err)
+	}
+	if want := "error in step 0 (require args): expected 2 args, got 1: unexpected number of args"; err.Error() != want {
+		t.Fatalf("expected %q, got: %v", want, err)
+	}
+}
+
+func TestChain_FinallyWithArgs(t *testing.T) {
+
+	first := func(ctx context.Context, args ...any) (int, error) {
+		args[0] = 0
+		return args[1].(int), nil
+	}
+
+	n, args, err := New[int](context.Background(), 1, 2, 3).FinallyWithArgs(first)
+	if err != nil || n != 2 {
+		t.Fatalf("unexpected result, got: %d, %v", n, err)
+	}
+	if len(args) != 3 || args[0] != 1 || args[1] != 2 || args[2] != 3 {
+		t.Fatalf("expected copy of args passed to final func, got: %v", args)
+	}
+
+	errFailed := errors.New("failed")
+	fail := func(ctx context.Context, args ...any) (int, error) {
+		return 0, errFailed
+	}
+
+	_, args, err = New[int](context.Background(), 1).FinallyWithArgs(fail)
+	if !errors.Is(err, errFailed) || len(args) != 1 || args[0] != 1 {
+		t.Fatalf("expected args despite failure, got: %v, %v", args, err)
+	}
+
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errFailed
+	}
+
+	_, args, err = New[int](context.Background(), 1).Then(step).FinallyWithArgs(first)
+	if !errors.Is(err, errFailed) || args != nil {
+		t.Fatalf("expected no args when final func not invoked, got: %v, %v", args, err)
+	}
+}
+
+func TestChain_FinallySafe(t *testing.T) {
+
+	type order struct {
+		ID  string
+		Qty int
+	}
+
+	explode := func(ctx context.Context, args ...any) (int, error) {
+		args[0] = nil
+		panic("Boom!")
+	}
+
+	for _, mode := range []PanicMode{PanicRecover, PanicPropagate} {
+		c := NewWithConfig[int](context.Background(), Config{PanicMode: mode}, order{ID: "a1", Qty: 3}, "eu")
+
+		_, args, err := c.FinallySafe(explode)
+
+		var pe *PanicError
+		if !errors.As(err, &pe) || pe.Value != "Boom!" {
+			t.Fatalf("mode %v: expected PanicError, got: %v", mode, err)
+		}
+		if len(args) != 2 || args[0] != (order{ID: "a1", Qty: 3}) || args[1] != "eu" {
+			t.Fatalf("mode %v: expected args intact, got: %v", mode, args)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package chain
+
+import (
+	"errors"
+	"expvar"
+	"sync"
+	"sync/atomic"
+)
+
+// expvarMetrics holds the counters published by EnableExpvarMetrics
+type expvarMetrics struct {
+	chainsStarted        *expvar.Int
+	stepsRun             *expvar.Int
+	retries              *expvar.Int
+	panics               *expvar.Int
+	contextCancellations *expvar.Int
+}
+
+var (
+	metrics       atomic.Pointer[expvarMetrics]
+	enableMetrics sync.Once
+)
+
+// EnableExpvarMetrics publishes counters for all chains via expvar, as the variables:
+//
+//   - chain.chains_started: chains created
+//   - chain.steps_run: steps executed, including final funcs
+//   - chain.retries: attempts made beyond the first
+//   - chain.panics: panics recovered from funcs
+//   - chain.context_cancellations: chains ended by their context being done
+//
+// The counters are updated atomically, so are safe for concurrent chains.  Until enabled,
+// no counters are maintained.  Calling EnableExpvarMetrics again has no effect.
+func EnableExpvarMetrics() {
+	enableMetrics.Do(func() {
+		metrics.Store(&expvarMetrics{
+			chainsStarted:        expvar.NewInt("chain.chains_started"),
+			stepsRun:             expvar.NewInt("chain.steps_run"),
+			retries:              expvar.NewInt("chain.retries"),
+			panics:               expvar.NewInt("chain.panics"),
+			contextCancellations: expvar.NewInt("chain.context_cancellations"),
+		})
+	})
+}
+
+// The methods below are no-ops on a nil receiver, which is the case until enabled
+
+// chainStarted counts a chain being created
+func (m *expvarMetrics) chainStarted() {
+	if m != nil {
+		m.chainsStarted.Add(1)
+	}
+}
+
+// stepRun counts a step being executed with the number of attempts made
+func (m *expvarMetrics) stepRun(attempts int) {
+	if m != nil {
+		m.stepsRun.Add(1)
+		if attempts > 1 {
+			m.retries.Add(int64(attempts - 1))
+		}
+	}
+}
+
+// panicked counts a panic being recovered
+func (m *expvarMetrics) panicked() {
+	if m != nil {
+		m.panics.Add(1)
+	}
+}
+
+// ended counts a chain ending with err
+func (m *expvarMetrics) ended(err error) {
+	if m != nil && errors.Is(err, ErrContextDone) {
+		m.contextCancellations.Add(1)
+	}
+}
@@ -0,0 +1,83 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"testing"
+)
+
+func TestEnableExpvarMetrics(t *testing.T) {
+
+	EnableExpvarMetrics()
+	EnableExpvarMetrics() // Safe to call more than once
+
+	names := []string{
+		"chain.chains_started",
+		"chain.steps_run",
+		"chain.retries",
+		"chain.panics",
+		"chain.context_cancellations",
+	}
+
+	snapshot := func() map[string]int64 {
+		out := map[string]int64{}
+		for _, name := range names {
+			v, ok := expvar.Get(name).(*expvar.Int)
+			if !ok {
+				t.Fatalf("expected %s to be published", name)
+			}
+			out[name] = v.Value()
+		}
+		return out
+	}
+
+	before := snapshot()
+
+	calls := 0
+	flaky := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		if calls < 3 {
+			return nil, errors.New("transient")
+		}
+		return args, nil
+	}
+
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	boom := func(ctx context.Context, args ...any) ([]any, error) {
+		panic("Boom!")
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	// Succeeds after 2 retries: 2 steps
+	_, _ = NewWithRetries[int](context.Background(), Retry{NumRetries: 2, BaseWait: 1}).Then(flaky).Finally(f)
+
+	// Panics: 1 step
+	_, _ = New[int](context.Background()).Then(boom).Then(step).Finally(f)
+
+	// Context done before any step
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _ = New[int](ctx).Then(step).Finally(f)
+
+	after := snapshot()
+
+	want := map[string]int64{
+		"chain.chains_started":        3,
+		"chain.steps_run":             3,
+		"chain.retries":               2,
+		"chain.panics":                1,
+		"chain.context_cancellations": 1,
+	}
+	for _, name := range names {
+		if got := after[name] - before[name]; got != want[name] {
+			t.Fatalf("%s: expected %d, got: %d", name, want[name], got)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrArgTypeMismatch is raised when an arg is not of the type required
+var ErrArgTypeMismatch = errors.New("arg type mismatch")
+
+// ThenFilter adds a step that keeps only those args that satisfy pred, preserving their order
+func (c Chain[T]) ThenFilter(pred func(any) bool) Chain[T] {
+	return c.queue(pred, check(ErrNilThenFunc, pred), func(c Chain[T]) Chain[T] {
+		var g Func
+		if pred != nil {
+			g = func(ctx context.Context, args ...any) ([]any, error) {
+				return filter(pred, args), nil
+			}
+		}
+		return c.then(g, pred)
+	})
+}
+
+// Filter adds a step that keeps only those args for which pred returns true, preserving
+// their order.  Should pred fail for any arg then the step fails, with the error
+// identifying the index of the arg.  If no args are kept then the new args are empty.
+func (c Chain[T]) Filter(pred func(context.Context, any) (bool, error)) Chain[T] {
+	return c.queue(pred, check(ErrNilThenFunc, pred), func(c Chain[T]) Chain[T] {
+		var g Func
+		if pred != nil {
+			g = func(ctx context.Context, args ...any) ([]any, error) {
+				out := []any{}
+				for i, arg := range args {
+					keep, err := pred(ctx, arg)
+					if err != nil {
+						return nil, fmt.Errorf("arg %d: %w", i, err)
+					}
+					if keep {
+						out = append(out, arg)
+					}
+				}
+				return out, nil
+			}
+		}
+		return c.then(g, pred)
+	})
+}
+
+// FinallyFilter returns a FinalFunc that keeps only those args that satisfy pred, returning
+// them as a typed slice.  Kept args that are not of type E raise ErrArgTypeMismatch,
+// identifying the index of the offending arg.
+func FinallyFilter[E any](pred func(any) bool) FinalFunc[[]E] {
+	if pred == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, args ...any) ([]E, error) {
+		out := []E{}
+		for i, arg := range args {
+			if !pred(arg) {
+				continue
+			}
+			e, ok := arg.(E)
+			if !ok {
+				return nil, fmt.Errorf("arg %d is %T, not %v: %w", i, arg, reflect.TypeFor[E](), ErrArgTypeMismatch)
+			}
+			out = append(out, e)
+		}
+		return out, nil
+	}
+}
+
+func filter(pred func(any) bool, args []any) []any {
+	out := []any{}
+	for _, arg := range args {
+		if pred(arg) {
+			out = append(out, arg)
+		}
+	}
+	return out
+}
@@ -0,0 +1,152 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleFinallyFilter() {
+
+	ints := func(v any) bool {
+		_, ok := v.(int)
+		return ok
+	}
+
+	even := func(v any) bool {
+		return v.(int)%2 == 0
+	}
+
+	result, _ := New[[]int](context.Background(), 1, "a", 2, 3.0, 4).
+		ThenFilter(ints).
+		Finally(FinallyFilter[int](even))
+
+	fmt.Println("Result:", result)
+	// Output: Result: [2 4]
+}
+
+func TestChain_ThenFilter(t *testing.T) {
+
+	boom := func(v any) bool {
+		panic("Filter Boom!")
+	}
+
+	_, err := New[[]int](context.Background(), 1, 2).
+		ThenFilter(boom).
+		Finally(FinallyFilter[int](func(any) bool { return true }))
+
+	if !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected caught panic error, got: %v", err)
+	}
+
+	_, err = New[[]int](context.Background(), 1, 2).
+		ThenFilter(nil).
+		Finally(FinallyFilter[int](func(any) bool { return true }))
+
+	if !errors.Is(err, ErrNilThenFunc) {
+		t.Fatalf("expected NilThen error, got: %v", err)
+	}
+}
+
+func TestFinallyFilter(t *testing.T) {
+
+	all := func(any) bool { return true }
+
+	result, err := New[[]int](context.Background()).
+		Finally(FinallyFilter[int](all))
+
+	if err != nil || result == nil || len(result) != 0 {
+		t.Fatalf("expected empty result, got: %v, %v", result, err)
+	}
+
+	_, err = New[[]int](context.Background(), 1, 2, "three").
+		Finally(FinallyFilter[int](all))
+
+	if !errors.Is(err, ErrArgTypeMismatch) {
+		t.Fatalf("expected type mismatch error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "arg 2 is string, not int") {
+		t.Fatalf("expected error to identify the arg, got: %v", err)
+	}
+
+	_, err = New[[]int](context.Background(), 1).
+		Finally(FinallyFilter[int](nil))
+
+	if !errors.Is(err, ErrNilFinalFunc) {
+		t.Fatalf("expected NilFinally error, got: %v", err)
+	}
+}
+
+func TestChain_Filter(t *testing.T) {
+
+	keep := func(want func(int) bool) func(context.Context, any) (bool, error) {
+		return func(ctx context.Context, v any) (bool, error) {
+			return want(v.(int)), nil
+		}
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		return fmt.Sprintf("%v", args), nil
+	}
+
+	tests := []struct {
+		name string
+		pred func(context.Context, any) (bool, error)
+		want string
+	}{
+		{"keep all", keep(func(int) bool { return true }), "[1 2 3 4]"},
+		{"drop all", keep(func(int) bool { return false }), "[]"},
+		{"partial", keep(func(x int) bool { return x%2 == 0 }), "[2 4]"},
+	}
+
+	for _, test := range tests {
+		result, err := New[string](context.Background(), 1, 2, 3, 4).
+			Filter(test.pred).
+			Finally(f)
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error, got: %v", test.name, err)
+		}
+		if result != test.want {
+			t.Fatalf("%s: unexpected result.  wanted: %s, got: %s", test.name, test.want, result)
+		}
+	}
+}
+
+func TestChain_Filter_1(t *testing.T) {
+
+	errFailed := errors.New("failed")
+
+	fail := func(ctx context.Context, v any) (bool, error) {
+		if v.(int) == 3 {
+			return false, errFailed
+		}
+		return true, nil
+	}
+
+	boom := func(ctx context.Context, v any) (bool, error) {
+		panic("Filter Boom!")
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := New[int](context.Background(), 1, 2, 3).
+		Filter(fail).
+		Finally(f)
+
+	if !errors.Is(err, errFailed) || !strings.Contains(err.Error(), "arg 2: failed") {
+		t.Fatalf("expected predicate error with index, got: %v", err)
+	}
+
+	_, err = New[int](context.Background(), 1).
+		Filter(boom).
+		Finally(f)
+
+	if !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected caught panic error, got: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package chain
+
+import "context"
+
+// pair holds the two outputs of the func passed to Finally2
+type pair[A, B any] struct {
+	a A
+	b B
+}
+
+// retype returns the chain as a Chain[U], so that it can be ended by a FinalFunc[U]
+func retype[T, U any](c Chain[T]) Chain[U] {
+	c = c.resolve()
+	return Chain[U]{
+		ctx:           c.ctx,
+		cfg:           c.cfg,
+		args:          c.args,
+		err:           c.err,
+		compensations: c.compensations,
+		step:          c.step,
+		collected:     c.collected,
+	}
+}
+
+// Finally2 ends the pipeline as Finally, for a final func with two outputs, avoiding the
+// need to declare a struct to hold them.  As methods cannot have type parameters, the chain
+// is passed as an argument.  Both outputs are zero values if the chain fails.
+func Finally2[T, A, B any](c Chain[T], f func(context.Context, ...any) (A, B, error)) (A, B, error) {
+	var g FinalFunc[pair[A, B]]
+	if f != nil {
+		g = func(ctx context.Context, args ...any) (pair[A, B], error) {
+			a, b, err := f(ctx, args...)
+			if err != nil {
+				return pair[A, B]{}, err
+			}
+			return pair[A, B]{a: a, b: b}, nil
+		}
+	}
+
+	p, err := retype[T, pair[A, B]](c).finally(g, f)
+	return p.a, p.b, err
+}
@@ -0,0 +1,53 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleFinally2() {
+
+	stats := func(ctx context.Context, args ...any) (int, int, error) {
+		sum := 0
+		for _, arg := range args {
+			sum += arg.(int)
+		}
+		return sum, len(args), nil
+	}
+
+	sum, count, _ := Finally2(New[any](context.Background(), 1, 2, 3), stats)
+
+	fmt.Println("Sum:", sum, "Count:", count)
+	// Output: Sum: 6 Count: 3
+}
+
+func TestFinally2(t *testing.T) {
+
+	errFailed := errors.New("failed")
+
+	partial := func(ctx context.Context, args ...any) (string, int, error) {
+		return "partial", 42, errFailed
+	}
+
+	s, n, err := Finally2(New[any](context.Background()), partial)
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("expected underlying error, got: %v", err)
+	}
+	if s != "" || n != 0 {
+		t.Fatalf("expected zero values on failure, got: %q, %d", s, n)
+	}
+
+	boom := func(ctx context.Context, args ...any) (string, int, error) {
+		panic("Finally2 Boom!")
+	}
+
+	if _, _, err := Finally2(New[any](context.Background()), boom); !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected caught panic error, got: %v", err)
+	}
+
+	if _, _, err := Finally2[any, string, int](New[any](context.Background()), nil); !errors.Is(err, ErrNilFinalFunc) {
+		t.Fatalf("expected nil final func error, got: %v", err)
+	}
+}
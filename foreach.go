@@ -0,0 +1,56 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// ForEach adds a transformation step that applies f to each of the current args in turn,
+// with the results, in order, becoming the new args.  Should f fail for any arg then the
+// step fails, with the error identifying the index of the arg.
+func (c Chain[T]) ForEach(f func(context.Context, any) (any, error)) Chain[T] {
+	return c.queue(f, check(ErrNilThenFunc, f), func(c Chain[T]) Chain[T] {
+		var g Func
+		if f != nil {
+			g = func(ctx context.Context, args ...any) ([]any, error) {
+				out := make([]any, 0, len(args))
+				for i, arg := range args {
+					result, err := f(ctx, arg)
+					if err != nil {
+						return nil, fmt.Errorf("arg %d: %w", i, err)
+					}
+					out = append(out, result)
+				}
+				return out, nil
+			}
+		}
+		return c.then(g, f)
+	})
+}
+
+// ThenOn adds a transformation step that applies f to the arg at index alone, replacing it
+// with the result whilst leaving the other args unchanged.  Should there be no arg at index
+// then the step fails with ErrArgCount.
+func (c Chain[T]) ThenOn(index int, f func(context.Context, any) (any, error)) Chain[T] {
+	return c.queue(f, check(ErrNilThenFunc, f), func(c Chain[T]) Chain[T] {
+		var g Func
+		if f != nil {
+			g = func(ctx context.Context, args ...any) ([]any, error) {
+				if index < 0 || index >= len(args) {
+					return nil, fmt.Errorf("no arg %d in %d args: %w", index, len(args), ErrArgCount)
+				}
+
+				result, err := f(ctx, args[index])
+				if err != nil {
+					return nil, fmt.Errorf("arg %d: %w", index, err)
+				}
+
+				out := slices.Clone(args)
+				out[index] = result
+				return out, nil
+			}
+		}
+		return c.then(g, f)
+	})
+}
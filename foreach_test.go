@@ -0,0 +1,101 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestChain_ForEach(t *testing.T) {
+
+	double := func(ctx context.Context, v any) (any, error) {
+		return v.(int) * 2, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		return fmt.Sprintf("%v", args), nil
+	}
+
+	result, err := New[string](context.Background(), 1, 2, 3).
+		ForEach(double).
+		Finally(f)
+
+	if err != nil || result != "[2 4 6]" {
+		t.Fatalf("unexpected result, got: %v, %v", result, err)
+	}
+
+	result, err = New[string](context.Background()).
+		ForEach(double).
+		Finally(f)
+
+	if err != nil || result != "[]" {
+		t.Fatalf("expected empty args, got: %v, %v", result, err)
+	}
+}
+
+func TestChain_ForEach_1(t *testing.T) {
+
+	errOdd := errors.New("odd")
+
+	var visited []any
+	even := func(ctx context.Context, v any) (any, error) {
+		visited = append(visited, v)
+		if v.(int)%2 != 0 {
+			return nil, errOdd
+		}
+		return v, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := New[int](context.Background(), 2, 4, 5, 6).
+		ForEach(even).
+		Finally(f)
+
+	if !errors.Is(err, errOdd) {
+		t.Fatalf("expected underlying error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "arg 2: odd") {
+		t.Fatalf("expected index of failing arg, got: %v", err)
+	}
+	if len(visited) != 3 {
+		t.Fatalf("expected remaining args to be skipped, got: %v", visited)
+	}
+}
+
+func TestChain_ThenOn(t *testing.T) {
+
+	upper := func(ctx context.Context, arg any) (any, error) {
+		return strings.ToUpper(arg.(string)), nil
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		return fmt.Sprintf("%v", args), nil
+	}
+
+	s, err := New[string](context.Background(), 1, "b", 3).ThenOn(1, upper).Finally(f)
+	if err != nil || s != "[1 B 3]" {
+		t.Fatalf("expected only the indexed arg to be transformed, got: %q, %v", s, err)
+	}
+
+	for _, index := range []int{-1, 3} {
+		_, err = New[string](context.Background(), 1, "b", 3).ThenOn(index, upper).Finally(f)
+		if !errors.Is(err, ErrArgCount) || errors.Is(err, ErrUnhandledPanic) {
+			t.Fatalf("index %d: expected arg count error, got: %v", index, err)
+		}
+	}
+
+	errFailed := errors.New("failed")
+	fail := func(ctx context.Context, arg any) (any, error) {
+		return nil, errFailed
+	}
+
+	_, err = New[string](context.Background(), 1, "b").ThenOn(0, fail).Finally(f)
+	if !errors.Is(err, errFailed) || !strings.Contains(err.Error(), "arg 0: failed") {
+		t.Fatalf("expected error identifying the arg, got: %v", err)
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/gford1000-go/chain
 
 go 1.24.4
+
+require golang.org/x/time v0.12.0
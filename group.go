@@ -0,0 +1,51 @@
+package chain
+
+import "sync"
+
+// Group coalesces concurrent calls by key, so that of many identical chains launched at
+// the same time only one is executed, with the others sharing its outcome.  Calls are
+// coalesced only whilst in flight: once a call completes, the next call for its key is
+// executed afresh.  The zero value is ready for use, and a Group must not be copied after
+// first use.
+type Group[T any] struct {
+	lck   sync.Mutex
+	calls map[string]*groupCall[T]
+}
+
+type groupCall[T any] struct {
+	wg  sync.WaitGroup
+	val T
+	err error
+}
+
+// Do executes fn for key, unless a call for key is already in flight, in which case it
+// waits for that call and returns its outcome.  The bool returned is true for the caller
+// whose fn was executed, the leader, and false for those sharing its outcome.  Should fn
+// panic then the panic propagates to the leader, with the others returning
+// ErrUnhandledPanic.
+func (g *Group[T]) Do(key string, fn func() (T, error)) (T, error, bool) {
+	g.lck.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*groupCall[T]{}
+	}
+	if call, ok := g.calls[key]; ok {
+		g.lck.Unlock()
+		call.wg.Wait()
+		return call.val, call.err, false
+	}
+
+	call := &groupCall[T]{err: ErrUnhandledPanic}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.lck.Unlock()
+
+	defer func() {
+		g.lck.Lock()
+		delete(g.calls, key)
+		g.lck.Unlock()
+		call.wg.Done()
+	}()
+
+	call.val, call.err = fn()
+	return call.val, call.err, true
+}
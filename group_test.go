@@ -0,0 +1,104 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup(t *testing.T) {
+
+	var g Group[int]
+	var runs atomic.Int32
+
+	release := make(chan struct{})
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		runs.Add(1)
+		<-release
+		return []any{args[0].(int) * 2}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	const n = 50
+
+	var wg sync.WaitGroup
+	var leaders atomic.Int32
+	results := make([]int, n)
+	errs := make([]error, n)
+
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var leader bool
+			results[i], errs[i], leader = g.Do("order-1", func() (int, error) {
+				return Process(context.Background(), []Func{step}, f, 21)
+			})
+			if leader {
+				leaders.Add(1)
+			}
+		}()
+	}
+
+	// Allow the callers to arrive before the leader completes
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if runs.Load() != 1 || leaders.Load() != 1 {
+		t.Fatalf("expected a single execution and leader, got: %d, %d", runs.Load(), leaders.Load())
+	}
+	for i := range n {
+		if results[i] != 42 || errs[i] != nil {
+			t.Fatalf("caller %d: expected shared result, got: %d, %v", i, results[i], errs[i])
+		}
+	}
+
+	// Once complete, the next call is executed afresh
+	if _, _, leader := g.Do("order-1", func() (int, error) { return 1, nil }); !leader {
+		t.Fatal("expected completed call not to be shared")
+	}
+}
+
+func TestGroup_1(t *testing.T) {
+
+	var g Group[string]
+
+	errFailed := errors.New("failed")
+	v, err, leader := g.Do("k", func() (string, error) { return "", errFailed })
+	if !errors.Is(err, errFailed) || v != "" || !leader {
+		t.Fatalf("expected error to be returned, got: %q, %v, %v", v, err, leader)
+	}
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	done := make(chan error)
+
+	go func() {
+		defer func() { _ = recover() }()
+		_, _, _ = g.Do("p", func() (string, error) {
+			close(started)
+			<-release
+			panic("Boom!")
+		})
+	}()
+
+	<-started
+	go func() {
+		_, err, _ := g.Do("p", func() (string, error) { return "unexpected", nil })
+		done <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	if err := <-done; !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected waiter to see unhandled panic, got: %v", err)
+	}
+}
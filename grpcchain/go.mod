@@ -0,0 +1,22 @@
+module github.com/gford1000-go/chain/grpcchain
+
+go 1.24.4
+
+require (
+	github.com/gford1000-go/chain v0.0.0-00010101000000-000000000000
+	google.golang.org/grpc v1.75.0
+)
+
+require (
+	golang.org/x/net v0.41.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.26.0 // indirect
+	golang.org/x/time v0.12.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250707201910-8d1bb00bc6a7 // indirect
+	google.golang.org/protobuf v1.36.6 // indirect
+)
+
+// The replace builds against the root module in this repository during development, and
+// is ignored by users of this module.  Before grpcchain is tagged, the root module must be
+// tagged and the require above updated to that version, see the README.
+replace github.com/gford1000-go/chain => ../
@@ -0,0 +1,35 @@
+package grpcchain
+
+import (
+	"context"
+
+	"github.com/gford1000-go/chain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor returns an interceptor that runs a chain of the funcs in fs before
+// each unary handler, so that cross-cutting steps such as authentication, validation and
+// enrichment are applied uniformly.  The chain starts with the request as its only arg, and
+// must end with a single arg, which is passed to the handler as the request.  Errors from
+// the chain are converted via ToGRPCStatus using mappers, whilst errors from the handler
+// are returned unchanged.
+func UnaryServerInterceptor(fs []chain.Func, mappers ...Mapper) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		c := chain.New[any](ctx, req)
+		for _, f := range fs {
+			c = c.Then(f)
+		}
+
+		args, err := c.Args()
+		if err != nil {
+			return nil, ToGRPCStatus(err, mappers...).Err()
+		}
+		if len(args) != 1 {
+			return nil, status.Errorf(codes.Internal, "chain for %s returned %d args, expected 1", info.FullMethod, len(args))
+		}
+
+		return handler(ctx, args[0])
+	}
+}
@@ -0,0 +1,95 @@
+package grpcchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gford1000-go/chain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+
+	var steps []string
+	step := func(name string) chain.Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			steps = append(steps, name)
+			return []any{fmt.Sprintf("%v+%s", args[0], name)}, nil
+		}
+	}
+
+	var handled any
+	handler := func(ctx context.Context, req any) (any, error) {
+		handled = req
+		return "response", nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/orders.Orders/Create"}
+	interceptor := UnaryServerInterceptor([]chain.Func{step("auth"), step("enrich")})
+
+	resp, err := interceptor(context.Background(), "req", info, handler)
+	if err != nil || resp != "response" {
+		t.Fatalf("unexpected response, got: %v, %v", resp, err)
+	}
+	if fmt.Sprint(steps) != "[auth enrich]" || handled != "req+auth+enrich" {
+		t.Fatalf("expected steps to run before handler with transformed request, got: %v, %v", steps, handled)
+	}
+
+	// Handler errors are returned unchanged
+	errHandler := status.Error(codes.NotFound, "no such order")
+	_, err = interceptor(context.Background(), "req", info, func(ctx context.Context, req any) (any, error) {
+		return nil, errHandler
+	})
+	if !errors.Is(err, errHandler) {
+		t.Fatalf("expected handler error, got: %v", err)
+	}
+}
+
+func TestUnaryServerInterceptor_1(t *testing.T) {
+
+	var called bool
+	handler := func(ctx context.Context, req any) (any, error) {
+		called = true
+		return nil, nil
+	}
+
+	pass := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/orders.Orders/Create"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := UnaryServerInterceptor([]chain.Func{pass})(ctx, "req", info, handler)
+	if status.Code(err) != codes.Canceled || called {
+		t.Fatalf("expected ErrContextDone to map to Canceled without calling handler, got: %v, %v", err, called)
+	}
+
+	errDenied := errors.New("denied")
+	deny := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errDenied
+	}
+	denied := func(err error) (codes.Code, bool) {
+		return codes.PermissionDenied, errors.Is(err, errDenied)
+	}
+
+	_, err = UnaryServerInterceptor([]chain.Func{deny}, denied)(context.Background(), "req", info, handler)
+	if status.Code(err) != codes.PermissionDenied || called {
+		t.Fatalf("expected mapped error, got: %v", err)
+	}
+
+	split := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0], args[0]}, nil
+	}
+
+	_, err = UnaryServerInterceptor([]chain.Func{split})(context.Background(), "req", info, handler)
+	if status.Code(err) != codes.Internal || called {
+		t.Fatalf("expected internal error for multiple args, got: %v", err)
+	}
+}
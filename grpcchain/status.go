@@ -0,0 +1,67 @@
+// Package grpcchain provides gRPC integration for chains, kept in a separate module from
+// the chain package so that neither it nor its users depend upon gRPC.
+package grpcchain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gford1000-go/chain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Mapper maps an error to a gRPC code, returning false if the error is not recognised
+type Mapper func(err error) (codes.Code, bool)
+
+// DefaultMapper maps the errors raised by chains to gRPC codes:
+//
+//   - chain.ErrContextDone maps to codes.DeadlineExceeded if the context's deadline was
+//     exceeded, otherwise to codes.Canceled
+//   - chain.ErrExceededRetries maps to codes.Unavailable
+//   - errors arising from invalid args, failed validation or chain construction map to
+//     codes.InvalidArgument
+//
+// All other errors are not recognised.
+func DefaultMapper(err error) (codes.Code, bool) {
+	switch {
+	case errors.Is(err, chain.ErrContextDone):
+		if errors.Is(err, context.DeadlineExceeded) {
+			return codes.DeadlineExceeded, true
+		}
+		return codes.Canceled, true
+	case errors.Is(err, chain.ErrExceededRetries):
+		return codes.Unavailable, true
+	case errors.Is(err, chain.ErrArgCount),
+		errors.Is(err, chain.ErrArgTypeMismatch),
+		errors.Is(err, chain.ErrLabelMismatch),
+		errors.Is(err, chain.ErrValidationFailed),
+		errors.Is(err, chain.ErrNilThenFunc),
+		errors.Is(err, chain.ErrNilFinalFunc):
+		return codes.InvalidArgument, true
+	}
+	return codes.Unknown, false
+}
+
+// ToGRPCStatus converts the error from a chain into a gRPC status.  The mappers are
+// consulted in order, allowing codes to be customised, before falling back to
+// DefaultMapper.  Errors recognised by none of the mappers map to codes.Internal.
+func ToGRPCStatus(err error, mappers ...Mapper) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+
+	for _, m := range mappers {
+		if m == nil {
+			continue
+		}
+		if code, ok := m(err); ok {
+			return status.New(code, err.Error())
+		}
+	}
+
+	if code, ok := DefaultMapper(err); ok {
+		return status.New(code, err.Error())
+	}
+	return status.New(codes.Internal, err.Error())
+}
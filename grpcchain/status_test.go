@@ -0,0 +1,85 @@
+package grpcchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gford1000-go/chain"
+	"google.golang.org/grpc/codes"
+)
+
+func TestToGRPCStatus(t *testing.T) {
+
+	pass := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errors.New("failed")
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expired, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-expired.Done()
+
+	run := func(ctx context.Context, retry chain.Retry, fs ...chain.Func) error {
+		_, err := chain.ProcessWithRetries(ctx, fs, f, retry)
+		return err
+	}
+
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{nil, codes.OK},
+		{run(context.Background(), chain.Retry{}, pass), codes.OK},
+		{run(cancelled, chain.Retry{}, pass), codes.Canceled},
+		{run(expired, chain.Retry{}, pass), codes.DeadlineExceeded},
+		{run(context.Background(), chain.Retry{NumRetries: 1, BaseWait: time.Millisecond}, fail), codes.Unavailable},
+		{run(context.Background(), chain.Retry{}, nil), codes.InvalidArgument},
+		{fmt.Errorf("error in validate: %w", chain.ErrValidationFailed), codes.InvalidArgument},
+		{run(context.Background(), chain.Retry{}, fail), codes.Internal},
+	}
+
+	for i, test := range tests {
+		if got := ToGRPCStatus(test.err).Code(); got != test.want {
+			t.Fatalf("test %d: wanted %v, got %v for: %v", i, test.want, got, test.err)
+		}
+	}
+}
+
+func TestToGRPCStatus_1(t *testing.T) {
+
+	errNotFound := errors.New("not found")
+
+	notFound := func(err error) (codes.Code, bool) {
+		return codes.NotFound, errors.Is(err, errNotFound)
+	}
+
+	s := ToGRPCStatus(fmt.Errorf("error in lookup: %w", errNotFound), nil, notFound)
+	if s.Code() != codes.NotFound {
+		t.Fatalf("expected custom mapping to apply, got: %v", s.Code())
+	}
+	if s.Message() != "error in lookup: not found" {
+		t.Fatalf("unexpected message, got: %v", s.Message())
+	}
+
+	// Custom mappers take precedence over the default mapping
+	retryable := func(err error) (codes.Code, bool) {
+		return codes.ResourceExhausted, errors.Is(err, chain.ErrExceededRetries)
+	}
+
+	if s := ToGRPCStatus(chain.ErrExceededRetries, retryable); s.Code() != codes.ResourceExhausted {
+		t.Fatalf("expected custom mapping to take precedence, got: %v", s.Code())
+	}
+}
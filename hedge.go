@@ -0,0 +1,76 @@
+package chain
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// ThenHedge adds a transformation step for latency sensitive, idempotent funcs.  Should the
+// func not have returned within after, a hedged copy is started, with up to maxHedges
+// copies started in addition to the original invocation, and the first successful result
+// being used.  A copy that fails causes the next hedge to be started immediately, and the
+// step fails only once all copies have failed, with the last error.  All copies share a
+// context that is cancelled as soon as the step completes, so losing copies must observe it
+// to finish promptly.
+func (c Chain[T]) ThenHedge(f Func, after time.Duration, maxHedges int) Chain[T] {
+	return c.queue(f, check(ErrNilThenFunc, f), func(c Chain[T]) Chain[T] {
+		if f == nil || maxHedges <= 0 {
+			return c.then(f, f)
+		}
+
+		g := func(ctx context.Context, args ...any) ([]any, error) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			type outcome struct {
+				result []any
+				err    error
+			}
+
+			// Buffered for every copy so that losing copies never block
+			outcomes := make(chan outcome, 1+maxHedges)
+
+			launched := 0
+			launch := func() {
+				launched++
+				go func() {
+					result, err := invoke(ctx, c.cfg.funcName(f), c.cfg.PanicMapper, f, slices.Clone(args))
+					outcomes <- outcome{result: result, err: err}
+				}()
+			}
+
+			timer := time.NewTimer(after)
+			defer timer.Stop()
+
+			launch()
+
+			failed := 0
+			for {
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case o := <-outcomes:
+					if o.err == nil {
+						return o.result, nil
+					}
+					failed++
+					if failed == launched {
+						if launched > maxHedges {
+							return nil, o.err
+						}
+						launch()
+						timer.Reset(after)
+					}
+				case <-timer.C:
+					if launched <= maxHedges {
+						launch()
+						timer.Reset(after)
+					}
+				}
+			}
+		}
+
+		return c.then(g, f)
+	})
+}
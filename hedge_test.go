@@ -0,0 +1,92 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestChain_ThenHedge(t *testing.T) {
+
+	var attempts atomic.Int32
+	lost := make(chan struct{})
+
+	// The first attempt stalls until cancelled, the hedge completes quickly
+	f1 := func(ctx context.Context, args ...any) ([]any, error) {
+		if attempts.Add(1) == 1 {
+			<-ctx.Done()
+			close(lost)
+			return nil, ctx.Err()
+		}
+		return []any{args[0].(int) + 1}, nil
+	}
+
+	f2 := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	start := time.Now()
+
+	result, err := New[int](context.Background(), 5).
+		ThenHedge(f1, 5*time.Millisecond, 2).
+		Finally(f2)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if result != 6 {
+		t.Fatalf("unexpected result.  wanted: 6, got: %v", result)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Fatalf("hedge did not reduce latency, took: %v", d)
+	}
+	if n := attempts.Load(); n != 2 {
+		t.Fatalf("unexpected number of attempts.  wanted: 2, got: %d", n)
+	}
+
+	select {
+	case <-lost:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("losing attempt was not cancelled")
+	}
+}
+
+func TestChain_ThenHedge_1(t *testing.T) {
+
+	var attempts atomic.Int32
+	errFailed := errors.New("failed")
+
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		attempts.Add(1)
+		return nil, errFailed
+	}
+
+	boom := func(ctx context.Context, args ...any) ([]any, error) {
+		panic("Hedge Boom!")
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := New[int](context.Background(), 5).
+		ThenHedge(fail, time.Second, 3).
+		Finally(f)
+
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("expected underlying error, got: %v", err)
+	}
+	if n := attempts.Load(); n != 4 {
+		t.Fatalf("expected failures to start hedges immediately, got attempts: %d", n)
+	}
+
+	_, err = New[int](context.Background(), 5).
+		ThenHedge(boom, time.Millisecond, 1).
+		Finally(f)
+
+	if !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected caught panic error, got: %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Handler returns an http.HandlerFunc that runs a chain of fs and fn for each request, with
+// the *http.Request as the only initial arg and the request's context as the chain's
+// context.  The result is written by encode.  Should the chain fail then a status is
+// written, as determined by HTTPStatus, with the error itself not disclosed to the client.
+// Should encode fail then http.StatusInternalServerError is written, which is only
+// effective if encode had not already written to the response.
+func Handler[T any](fs []Func, fn FinalFunc[T], encode func(http.ResponseWriter, T) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		result, err := Process(r.Context(), fs, fn, r)
+		if err != nil {
+			code := HTTPStatus(err)
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+		if encode == nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		if err := encode(w, result); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+	}
+}
+
+// HTTPStatus maps the error from a chain to an HTTP status code:
+//
+//   - panics map to http.StatusInternalServerError
+//   - ErrExceededRetries maps to http.StatusServiceUnavailable, even if the attempts
+//     timed out
+//   - failed validation and invalid args map to http.StatusBadRequest
+//   - ErrContextDone maps to http.StatusGatewayTimeout if the context's deadline was
+//     exceeded, and otherwise to http.StatusServiceUnavailable
+//   - all other errors map to http.StatusInternalServerError, including a deadline
+//     exceeded error returned by a func rather than raised by the chain
+func HTTPStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrUnhandledPanic):
+		return http.StatusInternalServerError
+	case errors.Is(err, ErrExceededRetries):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ErrValidationFailed),
+		errors.Is(err, ErrArgCount),
+		errors.Is(err, ErrArgTypeMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrContextDone):
+		if errors.Is(err, context.DeadlineExceeded) {
+			return http.StatusGatewayTimeout
+		}
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,149 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func ExampleHandler() {
+
+	parse := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0].(*http.Request).URL.Query().Get("name")}, nil
+	}
+
+	greet := func(ctx context.Context, args ...any) (string, error) {
+		return "hello " + args[0].(string), nil
+	}
+
+	encode := func(w http.ResponseWriter, s string) error {
+		_, err := fmt.Fprint(w, s)
+		return err
+	}
+
+	rec := httptest.NewRecorder()
+	Handler([]Func{parse}, greet, encode).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?name=world", nil))
+
+	fmt.Println(rec.Code, rec.Body.String())
+	// Output: 200 hello world
+}
+
+func TestHandler(t *testing.T) {
+
+	parse := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0].(*http.Request).URL.Query().Get("q")}, nil
+	}
+
+	validate := func(ctx context.Context, args ...any) ([]any, error) {
+		if args[0] == "" {
+			return nil, fmt.Errorf("missing q: %w", ErrValidationFailed)
+		}
+		return args, nil
+	}
+
+	process := func(ctx context.Context, args ...any) ([]any, error) {
+		switch args[0] {
+		case "panic":
+			panic("Boom!")
+		case "unavailable":
+			return nil, fmt.Errorf("%w: downstream", ErrExceededRetries)
+		case "fail":
+			return nil, errors.New("failed")
+		case "slow":
+			// The chain fails with ErrContextDone prior to the next step
+			<-ctx.Done()
+			return args, nil
+		}
+		return args, nil
+	}
+
+	render := func(ctx context.Context, args ...any) (string, error) {
+		return strings.ToUpper(args[0].(string)), nil
+	}
+
+	encode := func(w http.ResponseWriter, s string) error {
+		if s == "BAD" {
+			return errors.New("unable to encode")
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		_, err := fmt.Fprint(w, s)
+		return err
+	}
+
+	h := Handler([]Func{parse, validate, process}, render, encode)
+
+	tests := []struct {
+		q    string
+		code int
+		body string
+	}{
+		{"ok", http.StatusOK, "OK"},
+		{"", http.StatusBadRequest, "Bad Request\n"},
+		{"panic", http.StatusInternalServerError, "Internal Server Error\n"},
+		{"unavailable", http.StatusServiceUnavailable, "Service Unavailable\n"},
+		{"fail", http.StatusInternalServerError, "Internal Server Error\n"},
+		{"bad", http.StatusInternalServerError, "Internal Server Error\n"},
+	}
+
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?q="+test.q, nil))
+		if rec.Code != test.code || rec.Body.String() != test.body {
+			t.Fatalf("q=%q: expected %d %q, got: %d %q", test.q, test.code, test.body, rec.Code, rec.Body.String())
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?q=slow", nil).WithContext(ctx))
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected request context to be used, got: %d", rec.Code)
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+
+	slow := func(ctx context.Context, args ...any) ([]any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	// Attempts that time out are retried, and exhausting the retries is not a timeout of the request
+	cfg := Config{Retry: Retry{NumRetries: 1, AttemptTimeout: time.Millisecond}}
+	_, err := NewWithConfig[int](context.Background(), cfg).Then(slow).Finally(f)
+
+	if code := HTTPStatus(err); code != http.StatusServiceUnavailable {
+		t.Fatalf("expected %d, got: %d for %v", http.StatusServiceUnavailable, code, err)
+	}
+
+	// Only contexts done whilst the chain is running are mapped to timeouts
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{errContextDone(ctx, "f"), http.StatusServiceUnavailable},
+		{fmt.Errorf("%w: %w", ErrContextDone, context.DeadlineExceeded), http.StatusGatewayTimeout},
+		{context.DeadlineExceeded, http.StatusInternalServerError},
+		{&PanicError{Value: ErrContextDone}, http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		if code := HTTPStatus(test.err); code != test.code {
+			t.Fatalf("%v: expected %d, got: %d", test.err, test.code, code)
+		}
+	}
+}
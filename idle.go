@@ -0,0 +1,81 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrIdleTimeout is raised when a func invoked via ThenIdleTimeout does not report progress
+// within its idle window
+var ErrIdleTimeout = errors.New("no progress reported within idle timeout")
+
+// ProgressReporter is called by a func to indicate that it is still making progress,
+// resetting the idle timer of ThenIdleTimeout
+type ProgressReporter func()
+
+type progressKey struct{}
+
+// ProgressFrom returns the ProgressReporter held by the context.  If the func was not
+// invoked via ThenIdleTimeout then a no-op reporter is returned, so funcs can always
+// report progress safely.
+func ProgressFrom(ctx context.Context) ProgressReporter {
+	if p, ok := ctx.Value(progressKey{}).(ProgressReporter); ok {
+		return p
+	}
+	return func() {}
+}
+
+// ThenIdleTimeout adds a transformation step that fails with ErrIdleTimeout if the func
+// goes longer than idle without reporting progress via ProgressFrom.  The idle timer starts
+// when the func is invoked, so a func that never reports is treated as idle from the start.
+// The timer is restarted for each retry attempt, and expiry cancels the context passed to
+// the func, which must observe it.  An idle <= 0 disables the timer.
+func (c Chain[T]) ThenIdleTimeout(f Func, idle time.Duration) Chain[T] {
+	return c.queue(f, check(ErrNilThenFunc, f), func(c Chain[T]) Chain[T] {
+		if f == nil || idle <= 0 {
+			return c.then(f, f)
+		}
+
+		g := func(ctx context.Context, args ...any) ([]any, error) {
+			ctx, cancel := context.WithCancelCause(ctx)
+			defer cancel(nil)
+
+			progress := make(chan struct{}, 1)
+			report := ProgressReporter(func() {
+				select {
+				case progress <- struct{}{}:
+				default:
+				}
+			})
+
+			stop := make(chan struct{})
+			defer close(stop)
+
+			go func() {
+				timer := time.NewTimer(idle)
+				defer timer.Stop()
+				for {
+					select {
+					case <-stop:
+						return
+					case <-progress:
+						timer.Reset(idle)
+					case <-timer.C:
+						cancel(ErrIdleTimeout)
+						return
+					}
+				}
+			}()
+
+			result, err := f(context.WithValue(ctx, progressKey{}, report), args...)
+			if errors.Is(context.Cause(ctx), ErrIdleTimeout) {
+				return nil, fmt.Errorf("idle for %v, %w", idle, ErrIdleTimeout)
+			}
+			return result, err
+		}
+
+		return c.then(g, f)
+	})
+}
@@ -0,0 +1,71 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChain_ThenIdleTimeout(t *testing.T) {
+
+	// Runs for well beyond the idle window, but reports progress throughout
+	busy := func(ctx context.Context, args ...any) ([]any, error) {
+		progress := ProgressFrom(ctx)
+		for range 10 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(3 * time.Millisecond):
+				progress()
+			}
+		}
+		return []any{args[0].(int) + 1}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	result, err := New[int](context.Background(), 5).
+		ThenIdleTimeout(busy, 15*time.Millisecond).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if result != 6 {
+		t.Fatalf("unexpected result.  wanted: 6, got: %v", result)
+	}
+}
+
+func TestChain_ThenIdleTimeout_1(t *testing.T) {
+
+	// Never reports progress, so is idle from the start
+	silent := func(ctx context.Context, args ...any) ([]any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	start := time.Now()
+
+	_, err := New[int](context.Background(), 5).
+		ThenIdleTimeout(silent, 10*time.Millisecond).
+		Finally(f)
+
+	if !errors.Is(err, ErrIdleTimeout) {
+		t.Fatalf("expected idle timeout error, got: %v", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Fatalf("idle timeout was not prompt, took: %v", d)
+	}
+}
+
+func TestProgressFrom(t *testing.T) {
+	// Reporting outside of ThenIdleTimeout must be safe
+	ProgressFrom(context.Background())()
+}
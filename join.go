@@ -0,0 +1,82 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrNilJoinFunc is raised if any of the funcs passed to Join are nil
+var ErrNilJoinFunc = errors.New("funcs provided to Join cannot be nil")
+
+// Join supports fan-in from two independent upstream chains.  The chains are completed
+// concurrently using their respective final funcs, with merge combining the two outputs into
+// the initial args of the new chain.  Errors from either side are attributed to that side,
+// and if both sides fail then both errors are returned.  Should either side panic under
+// PanicPropagate then the panic is raised again by Join, once both sides have completed,
+// so that it can be recovered by the caller.
+func Join[T, X, Y any](ctx context.Context, a Chain[X], fa FinalFunc[X], b Chain[Y], fb FinalFunc[Y], merge func(X, Y) []any) Chain[T] {
+	if fa == nil || fb == nil || merge == nil {
+		return New[T](ctx).fail(ErrNilJoinFunc)
+	}
+
+	var (
+		wg     sync.WaitGroup
+		x      X
+		y      Y
+		errA   error
+		errB   error
+		panicA any
+		panicB any
+	)
+
+	// Panics are captured, as they cannot be recovered by the caller on these goroutines
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		defer func() { panicA = recover() }()
+		x, errA = a.Finally(fa)
+	}()
+	go func() {
+		defer wg.Done()
+		defer func() { panicB = recover() }()
+		y, errB = b.Finally(fb)
+	}()
+	wg.Wait()
+
+	if panicA != nil {
+		panic(panicA)
+	}
+	if panicB != nil {
+		panic(panicB)
+	}
+
+	if errA != nil {
+		errA = fmt.Errorf("left side of join: %w", errA)
+	}
+	if errB != nil {
+		errB = fmt.Errorf("right side of join: %w", errB)
+	}
+	if err := errors.Join(errA, errB); err != nil {
+		return New[T](ctx).fail(err)
+	}
+
+	args, err := joinMerge(merge, x, y)
+	if err != nil {
+		return New[T](ctx).fail(fmt.Errorf("error in %s: %w", runtimeFuncName(merge), err))
+	}
+
+	return New[T](ctx, args...)
+}
+
+func joinMerge[X, Y any](merge func(X, Y) []any, x X, y Y) (args []any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			args = nil
+			err = PanicMapper(nil).toError(runtimeFuncName(merge), r)
+		}
+	}()
+
+	return merge(x, y), nil
+}
@@ -0,0 +1,178 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleJoin() {
+
+	double := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int) * 2, nil
+	}
+
+	greet := func(ctx context.Context, args ...any) (string, error) {
+		return "hello " + args[0].(string), nil
+	}
+
+	merge := func(x int, y string) []any {
+		return []any{x, y}
+	}
+
+	describe := func(ctx context.Context, args ...any) (string, error) {
+		return fmt.Sprintf("%s: %d", args[1], args[0]), nil
+	}
+
+	ctx := context.Background()
+
+	result, _ := Join[string](ctx,
+		New[int](ctx, 21), double,
+		New[string](ctx, "world"), greet,
+		merge).
+		Finally(describe)
+
+	fmt.Println("Result:", result)
+	// Output: Result: hello world: 42
+}
+
+func TestJoin(t *testing.T) {
+
+	errLeft := errors.New("left failed")
+	errRight := errors.New("right failed")
+
+	left := func(ctx context.Context, args ...any) (int, error) {
+		return 0, errLeft
+	}
+
+	right := func(ctx context.Context, args ...any) (int, error) {
+		return 0, errRight
+	}
+
+	ok := func(ctx context.Context, args ...any) (int, error) {
+		return 1, nil
+	}
+
+	merge := func(x, y int) []any {
+		return []any{x + y}
+	}
+
+	ctx := context.Background()
+
+	_, err := Join[int](ctx, New[int](ctx), left, New[int](ctx), ok, merge).Finally(ok)
+	if !errors.Is(err, errLeft) {
+		t.Fatalf("expected left error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "left side of join") {
+		t.Fatalf("expected error to be attributed to left side, got: %v", err)
+	}
+
+	_, err = Join[int](ctx, New[int](ctx), left, New[int](ctx), right, merge).Finally(ok)
+	if !errors.Is(err, errLeft) || !errors.Is(err, errRight) {
+		t.Fatalf("expected both errors, got: %v", err)
+	}
+}
+
+func TestJoin_1(t *testing.T) {
+
+	ok := func(ctx context.Context, args ...any) (int, error) {
+		return 1, nil
+	}
+
+	boom := func(x, y int) []any {
+		panic("Merge Boom!")
+	}
+
+	ctx := context.Background()
+
+	_, err := Join[int](ctx, New[int](ctx), ok, New[int](ctx), ok, boom).Finally(ok)
+	if !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected caught panic error, got: %v", err)
+	}
+
+	_, err = Join[int](ctx, New[int](ctx), ok, New[int](ctx), nil, boom).Finally(ok)
+	if !errors.Is(err, ErrNilJoinFunc) {
+		t.Fatalf("expected nil join func error, got: %v", err)
+	}
+}
+
+func TestJoin_2(t *testing.T) {
+
+	errFailed := errors.New("failed")
+
+	fail := func(ctx context.Context, args ...any) (int, error) {
+		return 0, errFailed
+	}
+
+	right := func(ctx context.Context, args ...any) (string, error) {
+		return "b", nil
+	}
+
+	merge := func(x int, y string) []any {
+		return []any{x, y}
+	}
+
+	recovered := func(ctx context.Context, err error) ([]any, error) {
+		if !errors.Is(err, errFailed) {
+			return nil, err
+		}
+		return []any{"recovered"}, nil
+	}
+
+	id := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		return args[0].(string), nil
+	}
+
+	// A failed join is a usable chain, so can be recovered and continued
+	result, err := Join[string](context.Background(), New[int](context.Background()), fail, New[string](context.Background()), right, merge).
+		Catch(recovered).
+		Then(id).
+		Finally(f)
+
+	if err != nil || result != "recovered" {
+		t.Fatalf("expected recovery from failed join, got: %q, %v", result, err)
+	}
+
+	result, err = Join[string](context.Background(), New[int](context.Background()), nil, New[string](context.Background()), right, merge).
+		Catch(func(ctx context.Context, err error) ([]any, error) { return []any{"nil func"}, nil }).
+		Then(id).
+		Finally(f)
+
+	if err != nil || result != "nil func" {
+		t.Fatalf("expected recovery from invalid join, got: %q, %v", result, err)
+	}
+}
+
+func TestJoin_3(t *testing.T) {
+
+	boom := func(ctx context.Context, args ...any) (int, error) {
+		panic("Boom!")
+	}
+
+	right := func(ctx context.Context, args ...any) (string, error) {
+		return "b", nil
+	}
+
+	merge := func(x int, y string) []any {
+		return []any{x, y}
+	}
+
+	a := NewWithConfig[int](context.Background(), Config{PanicMode: PanicPropagate})
+
+	// The panic is raised on the caller's goroutine, so can be recovered
+	recovered := func() (r any) {
+		defer func() { r = recover() }()
+		Join[string](context.Background(), a, boom, New[string](context.Background()), right, merge)
+		return nil
+	}()
+
+	if recovered != "Boom!" {
+		t.Fatalf("expected panic to be raised by Join, got: %v", recovered)
+	}
+}
@@ -0,0 +1,63 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+)
+
+// ErrLabelMismatch is raised by ThenLabeled if more labels are provided than the func
+// returns outputs
+var ErrLabelMismatch = errors.New("more labels than outputs")
+
+type labelsKey struct{}
+
+// ThenLabeled adds a transformation step whose outputs are associated with the labels
+// provided, by position.  Subsequent funcs can retrieve a labelled value by name via
+// LabeledArg, regardless of how the args have been reshaped in the meantime.
+// Labels may be fewer than the outputs, with the remaining outputs unlabelled, and an empty
+// label skips its position.  Should the func return fewer outputs than labels then the
+// chain fails with ErrLabelMismatch.  Reusing a label replaces its earlier value.
+func (c Chain[T]) ThenLabeled(f Func, labels ...string) Chain[T] {
+	return c.queue(f, check(ErrNilThenFunc, f), func(c Chain[T]) Chain[T] {
+		var g Func
+		if f != nil {
+			g = func(ctx context.Context, args ...any) ([]any, error) {
+				result, err := f(ctx, args...)
+				if err == nil && len(labels) > len(result) {
+					return nil, fmt.Errorf("%d labels for %d outputs: %w", len(labels), len(result), ErrLabelMismatch)
+				}
+				return result, err
+			}
+		}
+
+		// Only the outputs of f can be labelled, so not when the step failed but the chain
+		// continues
+		out, succeeded := c.thenSucceeded(g, f, c.cfg.Retry)
+		if out.err != nil || !succeeded {
+			return out
+		}
+
+		existing, _ := out.ctx.Value(labelsKey{}).(map[string]any)
+		labelled := maps.Clone(existing)
+		if labelled == nil {
+			labelled = map[string]any{}
+		}
+		for i, label := range labels {
+			if label != "" {
+				labelled[label] = out.args[i]
+			}
+		}
+
+		out.ctx = context.WithValue(out.ctx, labelsKey{}, labelled)
+		return out
+	}).uncheckpointable(replacesContext)
+}
+
+// LabeledArg returns the value associated with label by an earlier ThenLabeled step
+func LabeledArg(ctx context.Context, label string) (any, bool) {
+	labelled, _ := ctx.Value(labelsKey{}).(map[string]any)
+	v, ok := labelled[label]
+	return v, ok
+}
@@ -0,0 +1,131 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleChain_ThenLabeled() {
+
+	lookup := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{"u-123", "alice", 42}, nil
+	}
+
+	// Discards all but the age
+	age := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[2]}, nil
+	}
+
+	describe := func(ctx context.Context, args ...any) (string, error) {
+		userID, _ := LabeledArg(ctx, "userID")
+		return fmt.Sprintf("%v is %v", userID, args[0]), nil
+	}
+
+	result, _ := New[string](context.Background()).
+		ThenLabeled(lookup, "userID", "name").
+		Then(age).
+		Finally(describe)
+
+	fmt.Println("Result:", result)
+	// Output: Result: u-123 is 42
+}
+
+func TestChain_ThenLabeled(t *testing.T) {
+
+	one := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{1}, nil
+	}
+
+	two := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{2, 3}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		a, _ := LabeledArg(ctx, "a")
+		b, _ := LabeledArg(ctx, "b")
+		_, ok := LabeledArg(ctx, "")
+		return fmt.Sprint(a, b, ok), nil
+	}
+
+	result, err := New[string](context.Background()).
+		ThenLabeled(one, "a").
+		ThenLabeled(two, "", "b").
+		ThenLabeled(two, "a").
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if result != "2 3 false" {
+		t.Fatalf("unexpected result, got: %v", result)
+	}
+
+	_, err = New[string](context.Background()).
+		ThenLabeled(one, "a", "b").
+		Finally(f)
+
+	if !errors.Is(err, ErrLabelMismatch) {
+		t.Fatalf("expected label mismatch error, got: %v", err)
+	}
+}
+
+func TestChain_ThenLabeled_1(t *testing.T) {
+
+	errFail := errors.New("fail")
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errFail
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		_, ok := LabeledArg(ctx, "a")
+		return fmt.Sprint(ok), nil
+	}
+
+	// The failed step's error is collected, and there are no outputs to label
+	_, err := NewCollecting[string](context.Background(), 1).
+		ThenLabeled(fail, "a").
+		Finally(f)
+
+	if !errors.Is(err, errFail) {
+		t.Fatalf("expected collected error, got: %v", err)
+	}
+
+	// A step whose error is mapped to nil has no outputs to label either
+	cfg := Config{ErrorMapper: func(step string, err error) error { return nil }}
+
+	result, err := NewWithConfig[string](context.Background(), cfg, 1).
+		ThenLabeled(fail, "a").
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if result != "false" {
+		t.Fatalf("expected no label, got: %v", result)
+	}
+}
+
+func TestChain_ThenLabeled_2(t *testing.T) {
+
+	var calls int
+	one := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		return []any{1}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		v, _ := LabeledArg(ctx, "a")
+		return fmt.Sprint(v), nil
+	}
+
+	// Labels are held in the context, which is not saved, so could not be restored on resume
+	_, err := NewWithCheckpointer[string](context.Background(), newGobCheckpointer(), 0).
+		ThenLabeled(one, "a").
+		Finally(f)
+
+	if !errors.Is(err, ErrCheckpointFailed) || calls != 0 {
+		t.Fatalf("expected failure before any step runs, got: %v after %d calls", err, calls)
+	}
+}
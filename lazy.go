@@ -0,0 +1,150 @@
+package chain
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// node is a step that has been added to a chain but not yet executed.  Steps are executed
+// when the chain is resolved, which happens when the chain is ended by Finally or one of
+// its variants, or when its state is inspected via Args, Err or EncodeArgs.
+type node[T any] struct {
+	named            any // identifies the step, see nameOf
+	from             Chain[T]
+	op               func(Chain[T]) Chain[T]
+	check            error  // error detectable without executing the step, reported by DryRun
+	uncheckpointable string // why the step cannot be checkpointed, see checkpointable
+	nonStep          bool   // configures the chain, so is not counted or listed as a step
+	once             sync.Once
+	out              Chain[T]
+}
+
+// queue returns a chain that will apply op to c once resolved
+func (c Chain[T]) queue(named any, check error, op func(Chain[T]) Chain[T]) Chain[T] {
+	return Chain[T]{pending: &node[T]{named: named, from: c, op: op, check: check}}
+}
+
+// uncheckpointable marks the pending step as one that cannot be restored from a
+// checkpoint, for the reason given
+func (c Chain[T]) uncheckpointable(reason string) Chain[T] {
+	c.pending.uncheckpointable = reason
+	return c
+}
+
+// nonStep marks the pending node as one that configures the chain rather than adding a
+// step, such as Use and Carry
+func (c Chain[T]) nonStep() Chain[T] {
+	c.pending.nonStep = true
+	return c
+}
+
+// resolve executes any pending steps, returning the resulting chain.  The outcome of each
+// step is retained, so that chains sharing earlier steps execute them only once.  Steps
+// following one that short-circuited the chain are skipped.  No steps are executed should
+// the chain be unable to be checkpointed.
+func (c Chain[T]) resolve() Chain[T] {
+	if c.pending == nil {
+		return c
+	}
+	base, nodes := c.unresolved()
+	if err := base.checkpointable(nodes); err != nil {
+		return base.fail(err)
+	}
+	return c.run()
+}
+
+// run is resolve, without the check that the chain can be checkpointed
+func (c Chain[T]) run() Chain[T] {
+	n := c.pending
+	if n == nil {
+		return c
+	}
+	n.once.Do(func() {
+		// A propagated panic leaves the step failed, so that the chain remains usable
+		defer func() {
+			if r := recover(); r != nil {
+				n.out = n.from.run()
+				if n.out.err == nil {
+					n.out.args = nil
+					n.out.err = n.out.cfg.PanicMapper.toError(n.out.cfg.nameOf(n.named), r)
+				}
+				panic(r)
+			}
+		}()
+
+		from := n.from.run()
+		if from.short {
+			n.out = from
+			return
+		}
+		n.out = n.op(from).run()
+	})
+	return n.out
+}
+
+// check returns err if any of fs is nil
+func check[F any](err error, fs ...F) error {
+	for _, f := range fs {
+		if v := reflect.ValueOf(f); !v.IsValid() || (v.Kind() == reflect.Func && v.IsNil()) {
+			return err
+		}
+	}
+	return nil
+}
+
+// DryRun validates the chain without executing it, returning the first error that would
+// prevent it completing regardless of its inputs, such as a nil func, identified by its
+// position in the chain.  No func is invoked, the context is not checked, and the chain is
+// unaffected, so it can be subsequently ended by Finally as usual.
+func (c Chain[T]) DryRun(f FinalFunc[T]) error {
+	base, nodes := c.unresolved()
+	if base.err != nil {
+		return base.err
+	}
+	if err := base.checkpointable(nodes); err != nil {
+		return err
+	}
+
+	step := base.step
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if err := nodes[i].check; err != nil {
+			return fmt.Errorf("step %d: %w", step, err)
+		}
+		if !nodes[i].nonStep {
+			step++
+		}
+	}
+
+	if f == nil {
+		return fmt.Errorf("step %d: %w", step, ErrNilFinalFunc)
+	}
+	return nil
+}
+
+// unresolved returns the chain prior to any pending steps, together with the pending steps
+// in reverse order, without executing them
+func (c Chain[T]) unresolved() (Chain[T], []*node[T]) {
+	var nodes []*node[T]
+	for ; c.pending != nil; c = c.pending.from {
+		nodes = append(nodes, c.pending)
+	}
+	return c, nodes
+}
+
+// String describes the chain for debugging, listing the names of the steps added, together
+// with the number of args and the error of the chain prior to them.  No steps are executed.
+func (c Chain[T]) String() string {
+	base, nodes := c.unresolved()
+
+	names := make([]string, 0, len(nodes))
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if !nodes[i].nonStep {
+			names = append(names, base.cfg.nameOf(nodes[i].named))
+		}
+	}
+
+	return fmt.Sprintf("Chain[%v]{steps: [%s], args: %d, err: %v}",
+		reflect.TypeFor[T](), strings.Join(names, ", "), len(base.args), base.err)
+}
@@ -0,0 +1,293 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleChain_DryRun() {
+
+	double := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0].(int) * 2}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	c := New[int](context.Background(), 1).
+		Then(double).
+		Then(nil).
+		Then(double)
+
+	fmt.Println(c.DryRun(f))
+	// Output: step 1: func provided to Then cannot be nil
+}
+
+func TestChain_DryRun(t *testing.T) {
+
+	var calls int
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		calls++
+		return 0, nil
+	}
+
+	c := New[int](context.Background(), 1).
+		Then(step).
+		ThenWithRetry(step, Retry{NumRetries: 1}).
+		Tap(nil).
+		Then(step)
+
+	err := c.DryRun(f)
+	if !errors.Is(err, ErrNilThenFunc) {
+		t.Fatalf("expected nil func error, got: %v", err)
+	}
+	if err.Error() != "step 2: func provided to Then cannot be nil" {
+		t.Fatalf("expected step to be identified, got: %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("expected no funcs to be invoked, got: %d", calls)
+	}
+}
+
+func TestChain_DryRun_1(t *testing.T) {
+
+	var calls int
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		calls++
+		return args[0].(int), nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := New[int](ctx, 1).Then(step).Then(step)
+
+	if err := c.DryRun(f); err != nil {
+		t.Fatalf("expected valid chain, got: %v", err)
+	}
+	if err := c.DryRun(nil); !errors.Is(err, ErrNilFinalFunc) || err.Error() != "step 2: func provided to Finally cannot be nil" {
+		t.Fatalf("expected nil final func error, got: %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("expected no funcs to be invoked, got: %d", calls)
+	}
+
+	// Chain is unaffected by the dry run
+	if _, err := c.Finally(f); !errors.Is(err, ErrContextDone) {
+		t.Fatalf("expected context done error, got: %v", err)
+	}
+}
+
+func TestChain_DryRun_2(t *testing.T) {
+
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	tests := []struct {
+		name string
+		c    Chain[int]
+		want error
+	}{
+		{"compensation", New[int](context.Background()).ThenWithCompensation(step, nil), ErrNilCompensation},
+		{"catch", New[int](context.Background()).Then(step).Catch(nil), ErrNilCatchFunc},
+		{"store", New[int](context.Background()).ThenCacheAside(nil, nil, step), ErrNilStore},
+		{"parallel", New[int](context.Background()).ThenParallel(step, nil), ErrNilThenFunc},
+		{"branch", New[int](context.Background()).Branch(func(...any) bool { return true }, []Func{step}, []Func{nil}), ErrNilThenFunc},
+		{"when", New[int](context.Background()).When(nil, step), ErrNilThenFunc},
+		{"validate", New[int](context.Background()).Validate(nil), ErrNilThenFunc},
+	}
+
+	for _, test := range tests {
+		if err := test.c.DryRun(f); !errors.Is(err, test.want) {
+			t.Fatalf("%s: expected %v, got: %v", test.name, test.want, err)
+		}
+	}
+}
+
+func TestChain_DryRun_3(t *testing.T) {
+
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	dynamic := func(ctx context.Context, args ...any) ([]Func, []any, error) {
+		return nil, args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	mw := func(f Func) Func { return f }
+
+	// Use and Carry configure the chain, so are not counted as steps
+	err := New[int](context.Background()).Use(mw).Carry("k", 1).Then(nil).DryRun(f)
+	if !errors.Is(err, ErrNilThenFunc) || !strings.HasPrefix(err.Error(), "step 0: ") {
+		t.Fatalf("expected step 0 to be reported, got: %v", err)
+	}
+
+	err = New[int](context.Background()).Use(mw).Then(step).Carry("k", 1).DryRun(nil)
+	if !errors.Is(err, ErrNilFinalFunc) || !strings.HasPrefix(err.Error(), "step 1: ") {
+		t.Fatalf("expected step 1 to be reported, got: %v", err)
+	}
+
+	err = NewWithCheckpointer[int](context.Background(), newGobCheckpointer()).
+		Use(mw).Carry("k", 1).Then(step).ThenDynamic(dynamic).DryRun(f)
+	if !errors.Is(err, ErrCheckpointFailed) || !strings.HasPrefix(err.Error(), "step 1 (") {
+		t.Fatalf("expected step 1 to be reported, got: %v", err)
+	}
+}
+
+func TestChain_Lazy(t *testing.T) {
+
+	var calls int
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		return []any{args[0].(int) + 1}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	c := New[int](context.Background(), 0).
+		Then(step).
+		ThenWithRetry(step, Retry{}).
+		Tap(func(ctx context.Context, args ...any) error { calls++; return nil }).
+		Then(step)
+
+	if calls != 0 {
+		t.Fatalf("expected no steps to run prior to Finally, got: %d", calls)
+	}
+
+	n, err := c.Finally(f)
+	if err != nil || n != 3 {
+		t.Fatalf("unexpected result, got: %d, %v", n, err)
+	}
+	if calls != 4 {
+		t.Fatalf("expected all steps to run at Finally, got: %d", calls)
+	}
+}
+
+func TestChain_Lazy_1(t *testing.T) {
+
+	var calls []int
+	step := func(i int, err error) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			calls = append(calls, i)
+			return args, err
+		}
+	}
+
+	errFailed := errors.New("failed")
+
+	c := New[int](context.Background()).
+		Then(step(0, nil)).
+		Then(step(1, errFailed)).
+		Then(step(2, nil))
+
+	if len(calls) != 0 {
+		t.Fatalf("expected no steps to run prior to Finally, got: %v", calls)
+	}
+
+	_, err := c.Finally(func(ctx context.Context, args ...any) (int, error) { return 0, nil })
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("expected step error, got: %v", err)
+	}
+	if fmt.Sprint(calls) != "[0 1]" {
+		t.Fatalf("expected first failing step to abort the chain, got: %v", calls)
+	}
+}
+
+func TestChain_Lazy_2(t *testing.T) {
+
+	var calls int
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		return []any{args[0].(int) + 1}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	prefix := New[int](context.Background(), 0).Then(step).Then(step)
+
+	a, _ := prefix.Then(step).Finally(f)
+	b, _ := prefix.Finally(f)
+
+	if a != 3 || b != 2 {
+		t.Fatalf("unexpected results, got: %d, %d", a, b)
+	}
+	if calls != 3 {
+		t.Fatalf("expected shared prefix to run once, got: %d", calls)
+	}
+
+	// Inspecting the chain executes its steps
+	calls = 0
+	c := New[int](context.Background(), 0).Then(step)
+	if args, err := c.Args(); err != nil || calls != 1 || args[0] != 1 {
+		t.Fatalf("expected Args to execute steps, got: %v, %v, %d", args, err, calls)
+	}
+}
+
+func TestChain_String(t *testing.T) {
+
+	var calls int
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		return args, nil
+	}
+
+	c := NewWithConfig[int](context.Background(), Config{ShortNames: true}, 1).
+		Then(step).
+		ThenNamed("persist", step).
+		Spread()
+
+	want := "Chain[int]{steps: [" + strings.TrimPrefix(runtimeFuncName(step), "github.com/gford1000-go/") + ", persist, spread], args: 1, err: <nil>}"
+	if got := fmt.Sprint(c); got != want {
+		t.Fatalf("expected %q, got: %q", want, got)
+	}
+	if calls != 0 {
+		t.Fatalf("expected no steps to be executed, got: %d", calls)
+	}
+
+	if got := New[string](context.Background()).String(); got != "Chain[string]{steps: [], args: 0, err: <nil>}" {
+		t.Fatalf("unexpected string for empty chain, got: %q", got)
+	}
+}
+
+func TestChain_String_1(t *testing.T) {
+
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	c := New[int](context.Background()).
+		Use(func(f Func) Func { return f }).
+		Carry("k", 1).
+		ThenNamed("persist", step)
+
+	if got := c.String(); got != "Chain[int]{steps: [persist], args: 0, err: <nil>}" {
+		t.Fatalf("expected only steps to be listed, got: %q", got)
+	}
+}
@@ -0,0 +1,51 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// ThenLimits adds a transformation step with two limits on the duration of each attempt of
+// f: once soft has elapsed onSoft is called with the step's name (and a warning logged to
+// Config.Logger), and once hard has elapsed the context passed to f is cancelled, with the
+// attempt failing with an error wrapping context.DeadlineExceeded.  onSoft is called
+// concurrently with f, and may be nil if only the warning is required.  A limit <= 0 is
+// disabled.
+func (c Chain[T]) ThenLimits(f Func, soft, hard time.Duration, onSoft func(step string)) Chain[T] {
+	return c.queue(f, check(ErrNilThenFunc, f), func(c Chain[T]) Chain[T] {
+		if f == nil || (soft <= 0 && hard <= 0) {
+			return c.then(f, f)
+		}
+
+		name := c.cfg.funcName(f)
+
+		g := func(ctx context.Context, args ...any) ([]any, error) {
+			if soft > 0 {
+				timer := time.AfterFunc(soft, func() {
+					c.cfg.warn(ctx, "step exceeded soft limit", slog.String("step", name), slog.Duration("limit", soft))
+					if onSoft != nil {
+						onSoft(name)
+					}
+				})
+				defer timer.Stop()
+			}
+
+			if hard <= 0 {
+				return f(ctx, args...)
+			}
+
+			hctx, cancel := context.WithTimeout(ctx, hard)
+			defer cancel()
+
+			result, err := f(hctx, args...)
+			if hctx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+				return nil, fmt.Errorf("exceeded hard limit of %v: %w", hard, context.DeadlineExceeded)
+			}
+			return result, err
+		}
+
+		return c.then(g, f)
+	})
+}
@@ -0,0 +1,107 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestChain_ThenLimits(t *testing.T) {
+
+	slow := func(ctx context.Context, args ...any) ([]any, error) {
+		select {
+		case <-ctx.Done():
+			return nil, errors.New("abandoned")
+		case <-time.After(20 * time.Millisecond):
+			return args, nil
+		}
+	}
+
+	var warned atomic.Int32
+	onSoft := func(step string) {
+		warned.Add(1)
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	result, err := New[int](context.Background(), 5).
+		ThenLimits(slow, 5*time.Millisecond, time.Second, onSoft).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if result != 5 {
+		t.Fatalf("unexpected result.  wanted: 5, got: %v", result)
+	}
+	if n := warned.Load(); n != 1 {
+		t.Fatalf("expected soft limit to be reported once, got: %d", n)
+	}
+}
+
+func TestChain_ThenLimits_1(t *testing.T) {
+
+	hang := func(ctx context.Context, args ...any) ([]any, error) {
+		<-ctx.Done()
+		return nil, errors.New("abandoned")
+	}
+
+	var warned atomic.Int32
+	onSoft := func(step string) {
+		warned.Add(1)
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	start := time.Now()
+
+	_, err := New[int](context.Background(), 5).
+		ThenLimits(hang, time.Millisecond, 10*time.Millisecond, onSoft).
+		Finally(f)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded error, got: %v", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Fatalf("hard limit not applied, took: %v", d)
+	}
+	if n := warned.Load(); n != 1 {
+		t.Fatalf("expected soft limit to be reported once, got: %d", n)
+	}
+}
+
+func TestChain_ThenLimits_2(t *testing.T) {
+
+	var warned atomic.Int32
+	onSoft := func(step string) {
+		warned.Add(1)
+	}
+
+	fast := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := New[int](context.Background(), 5).
+		ThenLimits(fast, 5*time.Millisecond, 10*time.Millisecond, onSoft).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+
+	// The soft watchdog must have been stopped when the step completed
+	time.Sleep(20 * time.Millisecond)
+	if n := warned.Load(); n != 0 {
+		t.Fatalf("expected no soft limit report, got: %d", n)
+	}
+}
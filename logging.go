@@ -0,0 +1,41 @@
+package chain
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// NewWithLogger starts a new pipeline, logging each step to logger; see Config.Logger
+func NewWithLogger[T any](ctx context.Context, logger *slog.Logger, args ...any) Chain[T] {
+	return NewWithConfig[T](ctx, Config{Logger: logger}, args...)
+}
+
+// noLog is returned by logStep when the chain has no Logger, avoiding an allocation
+func noLog(int, error) {}
+
+// logStep logs the start of the named step if the chain has a Logger, returning the func
+// to be called with the number of attempts made and the error, if any, once it ends
+func (c Chain[T]) logStep(named any) func(int, error) {
+	if c.cfg.Logger == nil {
+		return noLog
+	}
+
+	ctx, logger, name, index := c.ctx, c.cfg.Logger, c.cfg.nameOf(named), c.step
+	logger.LogAttrs(ctx, slog.LevelDebug, "step started", slog.String("step", name), slog.Int("index", index))
+
+	start := time.Now()
+	return func(attempts int, err error) {
+		attrs := []slog.Attr{
+			slog.String("step", name),
+			slog.Int("index", index),
+			slog.Int("attempts", attempts),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if err != nil {
+			logger.LogAttrs(ctx, slog.LevelWarn, "step failed", append(attrs, slog.Any("error", err))...)
+			return
+		}
+		logger.LogAttrs(ctx, slog.LevelDebug, "step ended", attrs...)
+	}
+}
@@ -0,0 +1,109 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+)
+
+// recorder is a slog.Handler that captures the records logged
+type recorder struct {
+	lck     sync.Mutex
+	records []slog.Record
+}
+
+func (r *recorder) Enabled(context.Context, slog.Level) bool { return true }
+
+func (r *recorder) Handle(_ context.Context, rec slog.Record) error {
+	r.lck.Lock()
+	defer r.lck.Unlock()
+	r.records = append(r.records, rec)
+	return nil
+}
+
+func (r *recorder) WithAttrs([]slog.Attr) slog.Handler { return r }
+
+func (r *recorder) WithGroup(string) slog.Handler { return r }
+
+func attrsOf(rec slog.Record) map[string]slog.Value {
+	attrs := map[string]slog.Value{}
+	rec.Attrs(func(a slog.Attr) bool {
+		attrs[a.Key] = a.Value
+		return true
+	})
+	return attrs
+}
+
+func TestNewWithLogger(t *testing.T) {
+
+	rec := &recorder{}
+
+	errFailed := errors.New("failed")
+	calls := 0
+	flaky := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		if calls == 1 {
+			return nil, errFailed
+		}
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, errFailed
+	}
+
+	c := NewWithConfig[int](context.Background(), Config{Logger: slog.New(rec), Retry: Retry{NumRetries: 1, BaseWait: 1}})
+	_, err := c.NamedThen("flaky", flaky).NamedFinally("final", f)
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("expected final error, got: %v", err)
+	}
+
+	want := []struct {
+		level slog.Level
+		msg   string
+		step  string
+		index int64
+	}{
+		{slog.LevelDebug, "step started", "flaky", 0},
+		{slog.LevelDebug, "step ended", "flaky", 0},
+		{slog.LevelDebug, "step started", "final", 1},
+		{slog.LevelWarn, "step failed", "final", 1},
+	}
+
+	if len(rec.records) != len(want) {
+		t.Fatalf("expected %d records, got: %d", len(want), len(rec.records))
+	}
+	for i, w := range want {
+		r := rec.records[i]
+		attrs := attrsOf(r)
+		if r.Level != w.level || r.Message != w.msg || attrs["step"].String() != w.step || attrs["index"].Int64() != w.index {
+			t.Fatalf("record %d: expected %v %q for %s[%d], got: %v %q %v", i, w.level, w.msg, w.step, w.index, r.Level, r.Message, attrs)
+		}
+	}
+
+	ended := attrsOf(rec.records[1])
+	if ended["attempts"].Int64() != 2 || ended["duration"].Kind() != slog.KindDuration {
+		t.Fatalf("expected attempts and duration, got: %v", ended)
+	}
+	if failed := attrsOf(rec.records[3]); failed["error"].Any() == nil {
+		t.Fatalf("expected error attr, got: %v", failed)
+	}
+}
+
+func TestNewWithLogger_1(t *testing.T) {
+
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	c := New[int](context.Background())
+
+	allocs := testing.AllocsPerRun(100, func() {
+		c.logStep(step)(1, nil)
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations without a logger, got: %v", allocs)
+	}
+}
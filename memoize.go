@@ -0,0 +1,125 @@
+package chain
+
+import (
+	"container/list"
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// MemoizeOptions bound the memory used by the cache of a memoized func
+type MemoizeOptions struct {
+	// MaxEntries, if > 0, is the maximum number of results held, with the least recently
+	// used being evicted to make room.  Default = unbounded
+	MaxEntries int
+	// TTL, if > 0, is the time for which a result is held.  Default = held indefinitely
+	TTL time.Duration
+}
+
+// Memoize returns a Func that caches the results of f by the key derived from its args by
+// keyFn, so that f is invoked only once for each key.  Concurrent calls for a key that is
+// being computed wait for its result rather than invoking f again.  Errors are not cached,
+// so whilst calls waiting upon a failed call share its error, the next call for the key
+// invokes f again.  The cache is unbounded; see MemoizeWithOptions.  f should be pure, as
+// it is not invoked for cached keys.
+func Memoize(f Func, keyFn func(args ...any) string) Func {
+	return MemoizeWithOptions(f, keyFn, MemoizeOptions{})
+}
+
+// MemoizeWithOptions is Memoize, with the cache bounded by opts
+func MemoizeWithOptions(f Func, keyFn func(args ...any) string, opts MemoizeOptions) Func {
+	return memoize(f, keyFn, opts, time.Now)
+}
+
+type memoEntry struct {
+	key     string
+	ready   chan struct{} // closed once result and err are set
+	result  []any
+	err     error
+	expires time.Time
+	elem    *list.Element // position in the recency list, nil once removed
+}
+
+func memoize(f Func, keyFn func(args ...any) string, opts MemoizeOptions, now func() time.Time) Func {
+	if f == nil || keyFn == nil {
+		return nil
+	}
+
+	var lck sync.Mutex
+	entries := map[string]*memoEntry{}
+	recency := list.New() // most recently used at the front
+
+	remove := func(e *memoEntry) {
+		if entries[e.key] == e {
+			delete(entries, e.key)
+		}
+		if e.elem != nil {
+			recency.Remove(e.elem)
+			e.elem = nil
+		}
+	}
+
+	return func(ctx context.Context, args ...any) ([]any, error) {
+		key := keyFn(args...)
+
+		lck.Lock()
+		e, ok := entries[key]
+		if ok && opts.TTL > 0 && !e.expires.IsZero() && !now().Before(e.expires) {
+			remove(e)
+			ok = false
+		}
+		if ok {
+			recency.MoveToFront(e.elem)
+			lck.Unlock()
+
+			select {
+			case <-e.ready:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			if e.err != nil {
+				return nil, e.err
+			}
+			return slices.Clone(e.result), nil
+		}
+
+		e = &memoEntry{key: key, ready: make(chan struct{})}
+		entries[key] = e
+		e.elem = recency.PushFront(e)
+		if opts.MaxEntries > 0 && recency.Len() > opts.MaxEntries {
+			remove(recency.Back().Value.(*memoEntry))
+		}
+		lck.Unlock()
+
+		// Ensures waiters are released, and the entry discarded, should f panic
+		completed := false
+		defer func() {
+			if !completed {
+				lck.Lock()
+				remove(e)
+				lck.Unlock()
+				e.err = ErrUnhandledPanic
+				close(e.ready)
+			}
+		}()
+
+		result, err := f(ctx, args...)
+		completed = true
+
+		lck.Lock()
+		if err != nil {
+			remove(e)
+		} else {
+			e.result = slices.Clone(result)
+			if opts.TTL > 0 {
+				e.expires = now().Add(opts.TTL)
+			}
+		}
+		lck.Unlock()
+
+		e.err = err
+		close(e.ready)
+		return result, err
+	}
+}
@@ -0,0 +1,141 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func ExampleMemoize() {
+
+	square := func(ctx context.Context, args ...any) ([]any, error) {
+		fmt.Println("computing", args[0])
+		return []any{args[0].(int) * args[0].(int)}, nil
+	}
+
+	memo := Memoize(square, func(args ...any) string { return fmt.Sprint(args...) })
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	for _, n := range []int{3, 3, 4} {
+		result, _ := New[int](context.Background(), n).Then(memo).Finally(f)
+		fmt.Println(result)
+	}
+	// Output:
+	// computing 3
+	// 9
+	// 9
+	// computing 4
+	// 16
+}
+
+func TestMemoize(t *testing.T) {
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	slow := func(ctx context.Context, args ...any) ([]any, error) {
+		calls.Add(1)
+		<-release
+		return []any{args[0]}, nil
+	}
+
+	memo := Memoize(slow, func(args ...any) string { return fmt.Sprint(args...) })
+
+	var wg sync.WaitGroup
+	results := make([][]any, 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = memo(context.Background(), "k")
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Fatalf("expected concurrent calls for a key to share one invocation, got: %d", calls.Load())
+	}
+	for i, r := range results {
+		if len(r) != 1 || r[0] != "k" {
+			t.Fatalf("call %d: unexpected result, got: %v", i, r)
+		}
+	}
+
+	// Results are copies, so cannot be corrupted by callers
+	r, _ := memo(context.Background(), "k")
+	r[0] = "changed"
+	if r, _ := memo(context.Background(), "k"); r[0] != "k" || calls.Load() != 1 {
+		t.Fatalf("expected cached result to be unaffected, got: %v, %d", r, calls.Load())
+	}
+
+	if _, _ = memo(context.Background(), "other"); calls.Load() != 2 {
+		t.Fatalf("expected a different key to invoke f, got: %d", calls.Load())
+	}
+}
+
+func TestMemoize_1(t *testing.T) {
+
+	errFailed := errors.New("failed")
+	var calls int
+	flaky := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		if calls == 1 {
+			return nil, errFailed
+		}
+		return args, nil
+	}
+
+	memo := Memoize(flaky, func(args ...any) string { return fmt.Sprint(args...) })
+
+	if _, err := memo(context.Background(), 1); !errors.Is(err, errFailed) {
+		t.Fatalf("expected error, got: %v", err)
+	}
+	if _, err := memo(context.Background(), 1); err != nil || calls != 2 {
+		t.Fatalf("expected errors not to be cached, got: %v, %d", err, calls)
+	}
+	if _, err := memo(context.Background(), 1); err != nil || calls != 2 {
+		t.Fatalf("expected success to be cached, got: %v, %d", err, calls)
+	}
+
+	if Memoize(nil, func(args ...any) string { return "" }) != nil || Memoize(flaky, nil) != nil {
+		t.Fatal("expected nil Func for nil inputs")
+	}
+}
+
+func TestMemoizeWithOptions(t *testing.T) {
+
+	calls := map[any]int{}
+	f := func(ctx context.Context, args ...any) ([]any, error) {
+		calls[args[0]]++
+		return args, nil
+	}
+
+	key := func(args ...any) string { return fmt.Sprint(args...) }
+
+	now := time.Unix(0, 0)
+	memo := memoize(f, key, MemoizeOptions{MaxEntries: 2, TTL: time.Minute}, func() time.Time { return now })
+
+	for _, k := range []any{"a", "b", "a", "c", "a", "b"} {
+		_, _ = memo(context.Background(), k)
+	}
+
+	// "b" is least recently used when "c" is added, so is evicted
+	if calls["a"] != 1 || calls["b"] != 2 || calls["c"] != 1 {
+		t.Fatalf("expected least recently used entry to be evicted, got: %v", calls)
+	}
+
+	now = now.Add(time.Minute)
+	_, _ = memo(context.Background(), "a")
+	if calls["a"] != 2 {
+		t.Fatalf("expected expired entry to be recomputed, got: %v", calls)
+	}
+}
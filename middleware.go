@@ -0,0 +1,36 @@
+package chain
+
+import "slices"
+
+// Middleware wraps the func of a step, allowing cross-cutting behaviour to be applied to
+// steps without editing their funcs.  The func returned may invoke the func it wraps with
+// modified args, observe or replace its outputs and error, or return without invoking it.
+type Middleware func(Func) Func
+
+// Use registers mw to wrap the func of each step subsequently added to the chain, by Then
+// or any of its variants.  Middleware is applied in the order registered, with the first
+// registered being outermost.  As the wrapped func is invoked for each attempt, middleware
+// is also applied to each retry.  Middleware does not apply to the final func.
+func (c Chain[T]) Use(mw Middleware) Chain[T] {
+	return c.queue("use", check(ErrNilThenFunc, mw), func(c Chain[T]) Chain[T] {
+		if c.err != nil {
+			return c
+		}
+		if mw == nil {
+			return c.fail(ErrNilThenFunc)
+		}
+
+		next := c
+		// Clipped so that chains sharing earlier steps do not share middleware
+		next.middleware = append(slices.Clip(c.middleware), mw)
+		return next
+	}).nonStep()
+}
+
+// wrap applies the chain's middleware to f
+func (c Chain[T]) wrap(f Func) Func {
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		f = c.middleware[i](f)
+	}
+	return f
+}
@@ -0,0 +1,135 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleChain_Use() {
+
+	trace := func(name string) Middleware {
+		return func(next Func) Func {
+			return func(ctx context.Context, args ...any) ([]any, error) {
+				fmt.Println("before", name)
+				defer fmt.Println("after", name)
+				return next(ctx, args...)
+			}
+		}
+	}
+
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		fmt.Println("step")
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, _ = New[int](context.Background()).
+		Use(trace("outer")).
+		Use(trace("inner")).
+		Then(step).
+		Finally(f)
+
+	// Output:
+	// before outer
+	// before inner
+	// step
+	// after inner
+	// after outer
+}
+
+func TestChain_Use(t *testing.T) {
+
+	var calls []string
+	step := func(name string) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			calls = append(calls, name)
+			return []any{args[0].(int) + 1}, nil
+		}
+	}
+
+	// Modifies the args passed to the step
+	double := func(next Func) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			return next(ctx, args[0].(int)*2)
+		}
+	}
+
+	errSkipped := errors.New("skipped")
+	var observed []error
+
+	// Short-circuits steps given negative args, observing errors
+	guard := func(next Func) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			if args[0].(int) < 0 {
+				return nil, errSkipped
+			}
+			result, err := next(ctx, args...)
+			observed = append(observed, err)
+			return result, err
+		}
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	// Middleware applies only to steps added after it is registered
+	n, err := New[int](context.Background(), 1).
+		Then(step("a")).
+		Use(double).
+		Use(guard).
+		Then(step("b")).
+		Finally(f)
+
+	if err != nil || n != 5 || fmt.Sprint(calls) != "[a b]" || len(observed) != 1 {
+		t.Fatalf("unexpected result, got: %d, %v, %v, %v", n, err, calls, observed)
+	}
+
+	calls = nil
+	_, err = New[int](context.Background(), -1).
+		Use(guard).
+		Then(step("a")).
+		Finally(f)
+
+	if !errors.Is(err, errSkipped) || calls != nil {
+		t.Fatalf("expected middleware to short-circuit the step, got: %v, %v", err, calls)
+	}
+
+	_, err = New[int](context.Background(), 1).Use(nil).Then(step("a")).Finally(f)
+	if !errors.Is(err, ErrNilThenFunc) {
+		t.Fatalf("expected nil middleware error, got: %v", err)
+	}
+}
+
+func TestChain_Use_1(t *testing.T) {
+
+	var wrapped int
+	count := func(next Func) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			wrapped++
+			return next(ctx, args...)
+		}
+	}
+
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	// Chains sharing a prefix do not share middleware registered after it
+	base := New[int](context.Background()).Then(step)
+	_, _ = base.Use(count).Then(step).Finally(f)
+	_, _ = base.Then(step).Finally(f)
+
+	if wrapped != 1 {
+		t.Fatalf("expected middleware on one chain only, got: %d", wrapped)
+	}
+}
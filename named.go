@@ -0,0 +1,63 @@
+package chain
+
+import "context"
+
+// Attr is a key/value pair of metadata describing a step
+type Attr struct {
+	Key   string
+	Value any
+}
+
+type attrsKey struct{}
+
+// StepAttrs returns the attrs of the step currently being executed, as provided to ThenNamed.
+// This allows observability to be added around funcs, keeping the metadata with the step
+// definition.  Nil is returned if the step has no attrs.
+func StepAttrs(ctx context.Context) []Attr {
+	attrs, _ := ctx.Value(attrsKey{}).([]Attr)
+	return attrs
+}
+
+// ThenNamed adds a transformation step, with errors attributed to name rather than the
+// runtime name of f, which is useful for anonymous funcs.  If name is empty then the
+// runtime name of f is used.  The attrs are made available to the step via StepAttrs,
+// including to Config.Tracer, so that they can be recorded on the step's span; if none are
+// provided then the context is left unchanged, so there is no overhead.  Overrides for the
+// step may be supplied via WithStepConfig.
+func (c Chain[T]) ThenNamed(name string, f Func, attrs ...Attr) Chain[T] {
+	var named any = f
+	if name != "" {
+		named = name
+	}
+	return c.queue(named, check(ErrNilThenFunc, f), func(c Chain[T]) Chain[T] {
+		// The attrs are added to the chain's context for the step alone, so that they are
+		// also available to the step's middleware and Config.Tracer
+		ctx := c.ctx
+		if len(attrs) > 0 {
+			c.ctx = context.WithValue(ctx, attrsKey{}, attrs)
+		}
+
+		var out Chain[T]
+		if name == "" {
+			out = c.then(f, f)
+		} else {
+			out = c.thenStep(f, name)
+		}
+		out.ctx = ctx
+		return out
+	})
+}
+
+// NamedThen is equivalent to ThenNamed without attrs
+func (c Chain[T]) NamedThen(name string, f Func) Chain[T] {
+	return c.ThenNamed(name, f)
+}
+
+// NamedFinally ends the pipeline as Finally, with errors attributed to name rather than the
+// runtime name of f.  If name is empty then the runtime name of f is used.
+func (c Chain[T]) NamedFinally(name string, f FinalFunc[T]) (T, error) {
+	if name == "" {
+		return c.finally(f, f)
+	}
+	return c.finally(f, name)
+}
@@ -0,0 +1,127 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleChain_ThenNamed() {
+
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errors.New("failed")
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := New[int](context.Background()).
+		ThenNamed("lookup", fail).
+		Finally(f)
+
+	fmt.Println("Err:", err)
+	// Output: Err: error in step 0 (lookup): failed
+}
+
+func TestChain_ThenNamed(t *testing.T) {
+
+	var seen [][]Attr
+	record := func(ctx context.Context, args ...any) ([]any, error) {
+		seen = append(seen, StepAttrs(ctx))
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := New[int](context.Background()).
+		ThenNamed("tagged", record, Attr{Key: "tier", Value: "gold"}).
+		ThenNamed("untagged", record).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if fmt.Sprint(seen) != "[[{tier gold}] []]" || seen[1] != nil {
+		t.Fatalf("unexpected attrs, got: %v", seen)
+	}
+
+	_, err = New[int](context.Background()).
+		ThenNamed("missing", nil).
+		Finally(f)
+
+	if !errors.Is(err, ErrNilThenFunc) {
+		t.Fatalf("expected NilThen error, got: %v", err)
+	}
+}
+
+func TestChain_NamedThen(t *testing.T) {
+
+	pass := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	fail := func(ctx context.Context, args ...any) (int, error) {
+		return 0, errors.New("failed")
+	}
+
+	var steps []string
+	cfg := Config{
+		After: func(step string, timing Timing, err error) {
+			steps = append(steps, step)
+		},
+	}
+
+	_, err := NewWithConfig[int](context.Background(), cfg).
+		NamedThen("validate", pass).
+		NamedThen("", pass).
+		NamedFinally("persist", fail)
+
+	if err == nil || err.Error() != "error in step 2 (persist): failed" {
+		t.Fatalf("expected custom name in error, got: %v", err)
+	}
+	if len(steps) != 3 || steps[0] != "validate" || steps[1] != runtimeFuncName(pass) || steps[2] != "persist" {
+		t.Fatalf("unexpected step names, got: %v", steps)
+	}
+
+	_, err = New[int](context.Background()).
+		NamedFinally("", fail)
+
+	if want := "error in step 0 (" + runtimeFuncName(fail) + "): failed"; err == nil || err.Error() != want {
+		t.Fatalf("expected runtime name in error, got: %v", err)
+	}
+}
+
+func TestChain_ThenNamed_1(t *testing.T) {
+
+	pass := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	var traced [][]Attr
+	cfg := Config{
+		Tracer: func(ctx context.Context, step string, index int) (context.Context, func(int, error)) {
+			traced = append(traced, StepAttrs(ctx))
+			return ctx, func(int, error) {}
+		},
+	}
+
+	_, err := NewWithConfig[int](context.Background(), cfg).
+		ThenNamed("fetch", pass, Attr{Key: "table", Value: "orders"}).
+		Then(pass).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if len(traced) != 3 || fmt.Sprint(traced[0]) != "[{table orders}]" || traced[1] != nil || traced[2] != nil {
+		t.Fatalf("expected attrs to reach the tracer for their step alone, got: %v", traced)
+	}
+}
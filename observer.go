@@ -0,0 +1,35 @@
+package chain
+
+import (
+	"context"
+	"time"
+)
+
+// Observer is notified as each step of a chain is invoked.  The index is the zero-based
+// position of the step in the chain, and d the wall-clock duration of the step, including
+// any retries and the waits between them.
+type Observer interface {
+	StepStart(name string, index int)
+	StepEnd(name string, index int, d time.Duration, err error)
+}
+
+// NewWithObserver starts a new pipeline, notifying obs of each step
+func NewWithObserver[T any](ctx context.Context, obs Observer, args ...any) Chain[T] {
+	return NewWithConfig[T](ctx, Config{Observer: obs}, args...)
+}
+
+// observe notifies the chain's Observer, if any, of the start of the named step, returning
+// the func to be called with the outcome of the step
+func (c Chain[T]) observe(named any) func(error) {
+	if c.cfg.Observer == nil {
+		return func(error) {}
+	}
+
+	name := nameOf(named)
+	c.cfg.Observer.StepStart(name, c.step)
+
+	start := time.Now()
+	return func(err error) {
+		c.cfg.Observer.StepEnd(name, c.step, time.Since(start), err)
+	}
+}
@@ -0,0 +1,86 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type recordingObserver struct {
+	events    []string
+	durations []time.Duration
+}
+
+func (o *recordingObserver) StepStart(name string, index int) {
+	o.events = append(o.events, fmt.Sprintf("start %d %s", index, name))
+}
+
+func (o *recordingObserver) StepEnd(name string, index int, d time.Duration, err error) {
+	o.events = append(o.events, fmt.Sprintf("end %d %s %v", index, name, err))
+	o.durations = append(o.durations, d)
+}
+
+func TestNewWithObserver(t *testing.T) {
+
+	slow := func(ctx context.Context, args ...any) ([]any, error) {
+		<-time.After(time.Millisecond)
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		<-time.After(time.Millisecond)
+		return 0, nil
+	}
+
+	obs := &recordingObserver{}
+
+	_, err := NewWithObserver[int](context.Background(), obs).
+		ThenNamed("first", slow).
+		ThenNamed("second", slow).
+		NamedFinally("final", f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+
+	want := "[start 0 first end 0 first <nil> start 1 second end 1 second <nil> start 2 final end 2 final <nil>]"
+	if got := fmt.Sprint(obs.events); got != want {
+		t.Fatalf("unexpected events.  wanted: %s, got: %s", want, got)
+	}
+	for i, d := range obs.durations {
+		if d <= 0 {
+			t.Fatalf("expected non-zero duration for step %d, got: %v", i, d)
+		}
+	}
+}
+
+func TestNewWithObserver_1(t *testing.T) {
+
+	errFailed := errors.New("failed")
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errFailed
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	obs := &recordingObserver{}
+	cfg := Config{
+		Retry:    Retry{NumRetries: 2, BaseWait: 5 * time.Millisecond, Strategy: ConstantBackoff{}},
+		Observer: obs,
+	}
+
+	_, err := NewWithConfig[int](context.Background(), cfg).
+		ThenNamed("fail", fail).
+		Finally(f)
+
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if len(obs.durations) != 1 || obs.durations[0] < 10*time.Millisecond {
+		t.Fatalf("expected duration to include retry waits, got: %v", obs.durations)
+	}
+}
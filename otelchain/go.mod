@@ -0,0 +1,25 @@
+module github.com/gford1000-go/chain/otelchain
+
+go 1.24.4
+
+require (
+	github.com/gford1000-go/chain v0.0.0-00010101000000-000000000000
+	go.opentelemetry.io/otel v1.37.0
+	go.opentelemetry.io/otel/sdk v1.37.0
+	go.opentelemetry.io/otel/trace v1.37.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/otel/metric v1.37.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/time v0.12.0 // indirect
+)
+
+// The replace builds against the root module in this repository during development, and
+// is ignored by users of this module.  Before otelchain is tagged, the root module must be
+// tagged and the require above updated to that version, see the README.
+replace github.com/gford1000-go/chain => ../
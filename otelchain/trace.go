@@ -0,0 +1,74 @@
+// Package otelchain provides OpenTelemetry tracing for chains, kept in a separate module
+// from the chain package so that neither it nor its users depend upon OpenTelemetry.
+package otelchain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gford1000-go/chain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attribute keys recorded on each step's span
+const (
+	StepIndexKey    = attribute.Key("chain.step.index")
+	StepAttemptsKey = attribute.Key("chain.step.attempts")
+)
+
+// Tracer returns a chain.StepTracer that starts a span for each step using tracer.  The span
+// is a child of any span active in the chain's context, is named after the step, and is
+// carried by the context passed to the step's func, so that spans started by the func are
+// its children.  Any attrs of the step, provided to chain.ThenNamed, are set on the span
+// if it is recording.  On failure the error is recorded and the span's status set to Error.
+func Tracer(tracer trace.Tracer) chain.StepTracer {
+	return func(ctx context.Context, step string, index int) (context.Context, func(int, error)) {
+		ctx, span := tracer.Start(ctx, step, trace.WithAttributes(StepIndexKey.Int(index)))
+		if attrs := chain.StepAttrs(ctx); len(attrs) > 0 && span.IsRecording() {
+			span.SetAttributes(attributes(attrs)...)
+		}
+
+		return ctx, func(attempts int, err error) {
+			span.SetAttributes(StepAttemptsKey.Int(attempts))
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}
+	}
+}
+
+// WithTracing returns cfg with each step traced using tracer
+func WithTracing(cfg chain.Config, tracer trace.Tracer) chain.Config {
+	cfg.Tracer = Tracer(tracer)
+	return cfg
+}
+
+// attributes converts attrs to span attributes, with values of types not supported by
+// attributes recorded as strings
+func attributes(attrs []chain.Attr) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		key := attribute.Key(a.Key)
+		switch v := a.Value.(type) {
+		case string:
+			kvs = append(kvs, key.String(v))
+		case bool:
+			kvs = append(kvs, key.Bool(v))
+		case int:
+			kvs = append(kvs, key.Int(v))
+		case int64:
+			kvs = append(kvs, key.Int64(v))
+		case float64:
+			kvs = append(kvs, key.Float64(v))
+		case []string:
+			kvs = append(kvs, key.StringSlice(v))
+		default:
+			kvs = append(kvs, key.String(fmt.Sprint(v)))
+		}
+	}
+	return kvs
+}
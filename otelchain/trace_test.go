@@ -0,0 +1,158 @@
+package otelchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gford1000-go/chain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func attr(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracer(t *testing.T) {
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	ctx, root := tracer.Start(context.Background(), "root")
+
+	var stepSpan trace.SpanContext
+	pass := func(ctx context.Context, args ...any) ([]any, error) {
+		stepSpan = trace.SpanContextFromContext(ctx)
+		return args, nil
+	}
+
+	errFailed := errors.New("failed")
+	var attempts int
+	flaky := func(ctx context.Context, args ...any) ([]any, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errFailed
+		}
+		return args, nil
+	}
+
+	fail := func(ctx context.Context, args ...any) (int, error) {
+		return 0, errFailed
+	}
+
+	cfg := WithTracing(chain.Config{Retry: chain.Retry{NumRetries: 1, BaseWait: time.Millisecond}}, tracer)
+
+	_, err := chain.NewWithConfig[int](ctx, cfg).
+		ThenNamed("validate", pass).
+		ThenNamed("fetch", flaky).
+		NamedFinally("persist", fail)
+
+	root.End()
+
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 4 {
+		t.Fatalf("expected 4 spans, got: %d", len(spans))
+	}
+
+	for i, name := range []string{"validate", "fetch", "persist"} {
+		span := spans[i]
+		if span.Name() != name {
+			t.Fatalf("span %d: expected name %s, got: %s", i, name, span.Name())
+		}
+		if span.Parent().SpanID() != root.SpanContext().SpanID() {
+			t.Fatalf("span %d: expected to be a child of the active span", i)
+		}
+		if v := attr(span, StepIndexKey); v.AsInt64() != int64(i) {
+			t.Fatalf("span %d: unexpected index, got: %v", i, v.Emit())
+		}
+	}
+
+	if spans[0].SpanContext().SpanID() != stepSpan.SpanID() {
+		t.Fatal("expected step's func to receive the step's span")
+	}
+	if v := attr(spans[1], StepAttemptsKey); v.AsInt64() != 2 {
+		t.Fatalf("unexpected attempts, got: %v", v.Emit())
+	}
+	if spans[0].Status().Code != codes.Unset || spans[1].Status().Code != codes.Unset {
+		t.Fatal("expected successful steps not to have error status")
+	}
+	if spans[2].Status().Code != codes.Error || len(spans[2].Events()) != 1 {
+		t.Fatalf("expected failed step to record the error, got: %+v", spans[2].Status())
+	}
+}
+
+// countingStringer counts its conversions to a string
+type countingStringer struct {
+	n *int
+}
+
+func (c countingStringer) String() string {
+	*c.n++
+	return "counted"
+}
+
+func TestTracer_1(t *testing.T) {
+
+	pass := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	var conversions int
+
+	run := func(sampler sdktrace.Sampler) *tracetest.SpanRecorder {
+		recorder := tracetest.NewSpanRecorder()
+		tracer := sdktrace.NewTracerProvider(sdktrace.WithSampler(sampler), sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+		_, err := chain.NewWithConfig[int](context.Background(), WithTracing(chain.Config{}, tracer)).
+			ThenNamed("fetch", pass,
+				chain.Attr{Key: "table", Value: "orders"},
+				chain.Attr{Key: "shard", Value: 3},
+				chain.Attr{Key: "owner", Value: countingStringer{n: &conversions}}).
+			Finally(f)
+		if err != nil {
+			t.Fatalf("unexpected error, got: %v", err)
+		}
+		return recorder
+	}
+
+	spans := run(sdktrace.AlwaysSample()).Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got: %d", len(spans))
+	}
+	if v := attr(spans[0], "table"); v.AsString() != "orders" {
+		t.Fatalf("expected string attr on span, got: %v", v.Emit())
+	}
+	if v := attr(spans[0], "shard"); v.AsInt64() != 3 {
+		t.Fatalf("expected int attr on span, got: %v", v.Emit())
+	}
+	if v := attr(spans[0], "owner"); v.AsString() != "counted" {
+		t.Fatalf("expected stringer attr on span, got: %v", v.Emit())
+	}
+	if v := attr(spans[1], "table"); v.Type() != attribute.INVALID {
+		t.Fatalf("expected attrs only on the named step, got: %v", v.Emit())
+	}
+
+	// Attrs are not converted for spans that are not sampled
+	conversions = 0
+	if spans := run(sdktrace.NeverSample()).Ended(); len(spans) != 0 || conversions != 0 {
+		t.Fatalf("expected no attrs to be set, got: %d spans, %d conversions", len(spans), conversions)
+	}
+}
@@ -0,0 +1,66 @@
+package chain
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicMapper converts the value recovered from a panicking func into an error, allowing
+// known panics to be classified as typed, handleable errors
+type PanicMapper func(recovered any) error
+
+// PanicMode determines how a chain handles panics in its funcs
+type PanicMode int
+
+const (
+	// PanicRecover recovers panics, failing the chain with a PanicError, or the error
+	// returned by Config.PanicMapper
+	PanicRecover PanicMode = iota
+	// PanicPropagate allows panics to propagate to the caller of the chain, so that they can
+	// be handled uniformly by a recover further up the stack.  Compensations are not run.
+	// Panics in funcs invoked on separate goroutines, such as by ThenParallel and ThenHedge,
+	// are always recovered, as they could not otherwise be handled by the caller, other than
+	// by Join, which raises them again on the caller's goroutine.
+	PanicPropagate
+)
+
+// PanicError is the error raised when a func panics and the panic is not mapped to another
+// error by a PanicMapper.  It satisfies errors.Is(err, ErrUnhandledPanic), and should the
+// recovered value be an error then it is also reachable via errors.Is and errors.As.
+type PanicError struct {
+	// Value is the value recovered from the panic
+	Value any
+	// Step is the name of the func that panicked
+	Step  string
+	stack []byte
+}
+
+// Error returns the recovered value formatted with ErrUnhandledPanic
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Value, ErrUnhandledPanic)
+}
+
+// Unwrap returns ErrUnhandledPanic, together with the recovered value if it is an error
+func (e *PanicError) Unwrap() []error {
+	if err, ok := e.Value.(error); ok {
+		return []error{err, ErrUnhandledPanic}
+	}
+	return []error{ErrUnhandledPanic}
+}
+
+// StackTrace returns the stack of the goroutine that panicked, captured at recovery
+func (e *PanicError) StackTrace() []byte {
+	return e.stack
+}
+
+// toError returns the error for the value recovered from the named func.  A PanicError is
+// used if there is no mapper, or the mapper returns nil.  As the stack is captured here,
+// toError must be called from the deferred func that recovered the value.
+func (m PanicMapper) toError(name string, recovered any) error {
+	if m != nil {
+		if err := m(recovered); err != nil {
+			return err
+		}
+	}
+	return &PanicError{Value: recovered, Step: name, stack: debug.Stack()}
+}
@@ -0,0 +1,207 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var errOutOfStock = errors.New("out of stock")
+
+func ExamplePanicMapper() {
+
+	// Converts a known panic into a handleable error, leaving all others as unhandled
+	mapper := func(recovered any) error {
+		if recovered == "no stock" {
+			return errOutOfStock
+		}
+		return nil
+	}
+
+	reserve := func(ctx context.Context, args ...any) ([]any, error) {
+		panic("no stock")
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := NewWithConfig[int](context.Background(), Config{PanicMapper: mapper}).
+		Then(reserve).
+		Finally(f)
+
+	fmt.Println("Out of stock:", errors.Is(err, errOutOfStock))
+	// Output: Out of stock: true
+}
+
+func TestPanicMapper(t *testing.T) {
+
+	mapper := func(recovered any) error {
+		if recovered == "no stock" {
+			return errOutOfStock
+		}
+		return nil
+	}
+
+	boom := func(ctx context.Context, args ...any) (int, error) {
+		panic("Boom!")
+	}
+
+	noStock := func(ctx context.Context, args ...any) (int, error) {
+		panic("no stock")
+	}
+
+	_, err := NewWithConfig[int](context.Background(), Config{PanicMapper: mapper}).Finally(boom)
+	if !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected unmapped panic to be unhandled, got: %v", err)
+	}
+
+	_, err = NewWithConfig[int](context.Background(), Config{PanicMapper: mapper}).Finally(noStock)
+	if !errors.Is(err, errOutOfStock) || errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected mapped panic error, got: %v", err)
+	}
+
+	_, err = New[int](context.Background()).Finally(noStock)
+	if err.Error() != "error in step 0 ("+runtimeFuncName(noStock)+"): no stock: unhandled panic" {
+		t.Fatalf("expected default panic error, got: %v", err)
+	}
+}
+
+type stockError struct {
+	sku string
+}
+
+func (e *stockError) Error() string {
+	return "out of stock: " + e.sku
+}
+
+func TestPanicMapper_1(t *testing.T) {
+
+	reserve := func(ctx context.Context, args ...any) ([]any, error) {
+		panic(errOutOfStock)
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		panic(&stockError{sku: "A1"})
+	}
+
+	_, err := New[int](context.Background()).Then(reserve).Finally(f)
+	if !errors.Is(err, ErrUnhandledPanic) || !errors.Is(err, errOutOfStock) {
+		t.Fatalf("expected panic and sentinel errors, got: %v", err)
+	}
+	if want := "error in step 0 (" + runtimeFuncName(reserve) + "): out of stock: unhandled panic"; err.Error() != want {
+		t.Fatalf("expected %q, got: %v", want, err)
+	}
+
+	_, err = New[int](context.Background()).Finally(f)
+
+	var stockErr *stockError
+	if !errors.Is(err, ErrUnhandledPanic) || !errors.As(err, &stockErr) || stockErr.sku != "A1" {
+		t.Fatalf("expected recovered error to be reachable via errors.As, got: %v", err)
+	}
+}
+
+func explode(ctx context.Context, args ...any) ([]any, error) {
+	panic("Boom!")
+}
+
+func TestPanicError(t *testing.T) {
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := New[int](context.Background()).Then(explode).Finally(f)
+	if !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected unhandled panic, got: %v", err)
+	}
+
+	var pe *PanicError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected PanicError, got: %v", err)
+	}
+	if pe.Value != "Boom!" || pe.Step != runtimeFuncName(explode) {
+		t.Fatalf("expected recovered value and step, got: %v, %v", pe.Value, pe.Step)
+	}
+	if !strings.Contains(string(pe.StackTrace()), "chain.explode(") {
+		t.Fatalf("expected stack to reference panicking func, got: %s", pe.StackTrace())
+	}
+}
+
+func TestPanicError_1(t *testing.T) {
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		panic(errOutOfStock)
+	}
+
+	_, err := NewWithConfig[int](context.Background(), Config{ShortNames: true}).NamedFinally("reserve", f)
+
+	var pe *PanicError
+	if !errors.As(err, &pe) || pe.Step != "reserve" || !errors.Is(err, errOutOfStock) {
+		t.Fatalf("expected PanicError for named step wrapping sentinel, got: %v", err)
+	}
+	if len(pe.StackTrace()) == 0 {
+		t.Fatal("expected stack to be captured")
+	}
+}
+
+func TestPanicMode(t *testing.T) {
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	boom := func(ctx context.Context, args ...any) (int, error) {
+		panic("Boom!")
+	}
+
+	run := func(mode PanicMode, step Func, final FinalFunc[int]) (recovered any, err error) {
+		defer func() {
+			recovered = recover()
+		}()
+		_, err = NewWithConfig[int](context.Background(), Config{PanicMode: mode}).Then(step).Finally(final)
+		return nil, err
+	}
+
+	if r, err := run(PanicPropagate, explode, f); r != "Boom!" || err != nil {
+		t.Fatalf("expected step panic to propagate, got: %v, %v", r, err)
+	}
+	if r, _ := run(PanicPropagate, func(ctx context.Context, args ...any) ([]any, error) { return args, nil }, boom); r != "Boom!" {
+		t.Fatalf("expected final panic to propagate, got: %v", r)
+	}
+	if r, err := run(PanicRecover, explode, f); r != nil || !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected panic to be recovered, got: %v, %v", r, err)
+	}
+}
+
+func TestPanicMode_1(t *testing.T) {
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	pass := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	c := NewWithConfig[int](context.Background(), Config{PanicMode: PanicPropagate}).Then(explode).Then(pass)
+
+	func() {
+		defer func() {
+			if r := recover(); r != "Boom!" {
+				t.Fatalf("expected step panic to propagate, got: %v", r)
+			}
+		}()
+		_, _ = c.Finally(f)
+	}()
+
+	// The chain is failed by the propagated panic, rather than left in an unusable state
+	if err := c.Err(); !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected panic error after propagation, got: %v", err)
+	}
+	if _, err := c.Finally(f); !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected panic error from subsequent Finally, got: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// ThenParallel adds a step that invokes each of the funcs concurrently with the same args,
+// the new args being their results concatenated in the order of the funcs.  Each func is
+// invoked with the chain's retry policy and panic handling, and receives its own copy of
+// the args slice.  The first func to fail cancels the context passed to the others, which
+// should observe it, and its error fails the step once all funcs have returned.
+func (c Chain[T]) ThenParallel(fs ...Func) Chain[T] {
+	return c.queue("parallel", check(ErrNilThenFunc, fs...), func(c Chain[T]) Chain[T] {
+		if c.err != nil {
+			return c
+		}
+		if slices.ContainsFunc(fs, func(f Func) bool { return f == nil }) {
+			return c.fail(ErrNilThenFunc)
+		}
+
+		names := make([]string, len(fs))
+		for i, f := range fs {
+			names[i] = c.cfg.funcName(f)
+		}
+
+		parallel := func(ctx context.Context, args ...any) ([]any, error) {
+			results, err := c.parallel(ctx, names, fs, args)
+			if err != nil {
+				return nil, err
+			}
+			return slices.Concat(results...), nil
+		}
+
+		return c.thenWithRetry(parallel, "parallel", Retry{})
+	})
+}
+
+// ThenParallelNamed adds a step that invokes each of the branches concurrently with the
+// same args, as ThenParallel.  The new args are a single map[string]any, holding the first
+// result of each branch by its name, or nil for a branch that returns no results.  Errors
+// identify the branch by name.
+func (c Chain[T]) ThenParallelNamed(branches map[string]Func) Chain[T] {
+	return c.queue("parallel", check(ErrNilThenFunc, slices.Collect(maps.Values(branches))...), func(c Chain[T]) Chain[T] {
+		if c.err != nil {
+			return c
+		}
+
+		// Sorted so that the branches are started, and errors reported, deterministically
+		names := slices.Sorted(maps.Keys(branches))
+		fs := make([]Func, len(names))
+		for i, name := range names {
+			if fs[i] = branches[name]; fs[i] == nil {
+				return c.fail(ErrNilThenFunc)
+			}
+		}
+
+		parallel := func(ctx context.Context, args ...any) ([]any, error) {
+			results, err := c.parallel(ctx, names, fs, args)
+			if err != nil {
+				return nil, err
+			}
+
+			merged := make(map[string]any, len(names))
+			for i, name := range names {
+				merged[name] = nil
+				if len(results[i]) > 0 {
+					merged[name] = results[i][0]
+				}
+			}
+			return []any{merged}, nil
+		}
+
+		return c.thenWithRetry(parallel, "parallel", Retry{})
+	})
+}
+
+// parallel invokes each of fs concurrently, returning their results by position.  The
+// first to fail cancels the context passed to the others, with its error, attributed to
+// its name, being returned once all have returned.
+func (c Chain[T]) parallel(ctx context.Context, names []string, fs []Func, args []any) ([][]any, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		results  = make([][]any, len(fs))
+	)
+
+	for i, f := range fs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, _, err := call(ctx, names[i], c.cfg.Retry, c.cfg.PanicMapper, PanicRecover, f, slices.Clone(args))
+			if err != nil {
+				once.Do(func() {
+					firstErr = fmt.Errorf("error in %s: %w", names[i], err)
+					cancel()
+				})
+				return
+			}
+			results[i] = result
+		}()
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return results, nil
+}
@@ -0,0 +1,213 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestChain_ThenParallel(t *testing.T) {
+
+	// Later funcs complete first, but results must follow the order of the funcs
+	delayed := func(d time.Duration, out ...any) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			<-time.After(d)
+			return append([]any{args[0]}, out...), nil
+		}
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		return fmt.Sprintf("%v", args), nil
+	}
+
+	result, err := New[string](context.Background(), 0).
+		ThenParallel(
+			delayed(20*time.Millisecond, "a"),
+			delayed(10*time.Millisecond, "b", "c"),
+			delayed(0, "d"),
+		).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if result != "[0 a 0 b c 0 d]" {
+		t.Fatalf("unexpected result, got: %v", result)
+	}
+}
+
+func TestChain_ThenParallel_1(t *testing.T) {
+
+	errFailed := errors.New("failed")
+
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errFailed
+	}
+
+	cancelled := make(chan struct{})
+	wait := func(ctx context.Context, args ...any) ([]any, error) {
+		select {
+		case <-ctx.Done():
+			close(cancelled)
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return args, nil
+		}
+	}
+
+	boom := func(ctx context.Context, args ...any) ([]any, error) {
+		panic("Parallel Boom!")
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	start := time.Now()
+
+	_, err := New[int](context.Background()).
+		ThenParallel(wait, fail).
+		Finally(f)
+
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("expected first error, got: %v", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Fatalf("expected outstanding funcs to be cancelled, took: %v", d)
+	}
+	select {
+	case <-cancelled:
+	default:
+		t.Fatal("outstanding func did not observe cancellation")
+	}
+
+	_, err = New[int](context.Background()).
+		ThenParallel(boom, wait).
+		Finally(f)
+
+	if !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected caught panic error, got: %v", err)
+	}
+
+	_, err = New[int](context.Background()).
+		ThenParallel(wait, nil).
+		Finally(f)
+
+	if !errors.Is(err, ErrNilThenFunc) {
+		t.Fatalf("expected nil func error, got: %v", err)
+	}
+}
+
+func TestChain_ThenParallel_2(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	wait := func(ctx context.Context, args ...any) ([]any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	go func() {
+		<-time.After(10 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := New[int](ctx).
+		ThenParallel(wait, wait).
+		Finally(f)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation error, got: %v", err)
+	}
+}
+
+func TestChain_ThenParallelNamed(t *testing.T) {
+
+	price := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0].(int) * 10, "ignored"}, nil
+	}
+
+	stock := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0].(int) > 0}, nil
+	}
+
+	audit := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (map[string]any, error) {
+		return args[0].(map[string]any), nil
+	}
+
+	m, err := New[map[string]any](context.Background(), 3).
+		ThenParallelNamed(map[string]Func{"price": price, "stock": stock, "audit": audit}).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(m) != 3 || m["price"] != 30 || m["stock"] != true || m["audit"] != nil {
+		t.Fatalf("unexpected merged results, got: %v", m)
+	}
+	if _, ok := m["audit"]; !ok {
+		t.Fatal("expected branch with no results to be present")
+	}
+
+	_, err = New[map[string]any](context.Background(), 3).
+		ThenParallelNamed(map[string]Func{"price": price, "stock": nil}).
+		Finally(f)
+
+	if !errors.Is(err, ErrNilThenFunc) {
+		t.Fatalf("expected nil func error, got: %v", err)
+	}
+}
+
+func TestChain_ThenParallelNamed_1(t *testing.T) {
+
+	errFailed := errors.New("failed")
+
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		return nil, errFailed
+	}
+
+	var cancelled atomic.Bool
+	wait := func(ctx context.Context, args ...any) ([]any, error) {
+		<-ctx.Done()
+		cancelled.Store(true)
+		return nil, ctx.Err()
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := New[int](context.Background()).
+		ThenParallelNamed(map[string]Func{"inventory": fail, "pricing": wait}).
+		Finally(f)
+
+	if !errors.Is(err, errFailed) || !strings.Contains(err.Error(), "error in inventory: failed") {
+		t.Fatalf("expected first error attributed to its branch, got: %v", err)
+	}
+	if !cancelled.Load() {
+		t.Fatal("expected other branches to be cancelled")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = New[int](ctx).
+		ThenParallelNamed(map[string]Func{"a": wait, "b": wait}).
+		Finally(f)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context cancellation to abort the branches, got: %v", err)
+	}
+}
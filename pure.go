@@ -0,0 +1,18 @@
+package chain
+
+import "context"
+
+// ThenPure adds a transformation step for a func that cannot fail, avoiding the need for it
+// to return an error.  The step remains subject to the chain's context checks, and any panic
+// in f is recovered as for Then.
+func (c Chain[T]) ThenPure(f func(context.Context, ...any) []any) Chain[T] {
+	return c.queue(f, check(ErrNilThenFunc, f), func(c Chain[T]) Chain[T] {
+		var g Func
+		if f != nil {
+			g = func(ctx context.Context, args ...any) ([]any, error) {
+				return f(ctx, args...), nil
+			}
+		}
+		return c.then(g, f)
+	})
+}
@@ -0,0 +1,65 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleChain_ThenPure() {
+
+	double := func(ctx context.Context, args ...any) []any {
+		return []any{args[0].(int) * 2}
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	result, _ := New[int](context.Background(), 5).
+		ThenPure(double).
+		ThenPure(double).
+		Finally(f)
+
+	fmt.Println("Result:", result)
+	// Output: Result: 20
+}
+
+func TestChain_ThenPure(t *testing.T) {
+
+	swap := func(ctx context.Context, args ...any) []any {
+		return []any{args[1], args[0]}
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		return fmt.Sprint(args...), nil
+	}
+
+	s, err := New[string](context.Background(), "a", "b").ThenPure(swap).Finally(f)
+	if err != nil || s != "ba" {
+		t.Fatalf("expected args to be threaded, got: %q, %v", s, err)
+	}
+
+	boom := func(ctx context.Context, args ...any) []any {
+		panic("Boom!")
+	}
+
+	_, err = New[string](context.Background()).ThenPure(boom).Finally(f)
+	if !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected panic to be recovered, got: %v", err)
+	}
+
+	_, err = New[string](context.Background()).ThenPure(nil).Finally(f)
+	if !errors.Is(err, ErrNilThenFunc) {
+		t.Fatalf("expected nil func error, got: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = New[string](ctx, "a", "b").ThenPure(swap).Finally(f)
+	if !errors.Is(err, ErrContextDone) {
+		t.Fatalf("expected context done error, got: %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimit returns a Func that waits on limiter before each invocation of f, so that a
+// step calling a rate limited API is throttled.  As the wait is within the Func, each retry
+// attempt is also subject to the limiter, with the time spent waiting reported as the
+// WaitDuration of the step (see WaitFor).  Should the context be done during the wait then
+// f is not invoked and the error wraps ErrContextDone.  Other failures to wait, such as the
+// wait being certain to exceed the context's deadline, return the limiter's error.  The
+// limiter may be shared by many chains, to throttle them collectively.
+func RateLimit(f Func, limiter *rate.Limiter) Func {
+	if f == nil || limiter == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, args ...any) ([]any, error) {
+		if err := WaitFor(ctx, limiter.Wait); err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("during rate limit wait, %w: %w", ErrContextDone, err)
+			}
+			return nil, fmt.Errorf("unable to wait for rate limit: %w", err)
+		}
+		return f(ctx, args...)
+	}
+}
@@ -0,0 +1,109 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestRateLimit(t *testing.T) {
+
+	var times []time.Time
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		times = append(times, time.Now())
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	interval := 20 * time.Millisecond
+	limited := RateLimit(step, rate.NewLimiter(rate.Every(interval), 1))
+
+	start := time.Now()
+	_, err := New[int](context.Background()).Then(limited).Then(limited).Then(limited).Finally(f)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(times) != 3 {
+		t.Fatalf("expected 3 calls, got: %d", len(times))
+	}
+	// The first call uses the burst, with the remainder spaced by the limiter
+	if d := time.Since(start); d < 2*interval-5*time.Millisecond {
+		t.Fatalf("expected calls to be spaced by the limiter, took: %v", d)
+	}
+	for i := 1; i < len(times); i++ {
+		if d := times[i].Sub(times[i-1]); d < interval-5*time.Millisecond {
+			t.Fatalf("call %d too soon after previous: %v", i, d)
+		}
+	}
+}
+
+func TestRateLimit_1(t *testing.T) {
+
+	var calls int
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		return args, nil
+	}
+
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	limited := RateLimit(step, limiter)
+
+	if _, err := limited(context.Background()); err != nil || calls != 1 {
+		t.Fatalf("expected burst to allow first call, got: %v, %d", err, calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	if _, err := limited(ctx); !errors.Is(err, ErrContextDone) || calls != 1 {
+		t.Fatalf("expected pending wait to be aborted, got: %v, %d", err, calls)
+	}
+
+	if RateLimit(nil, limiter) != nil || RateLimit(step, nil) != nil {
+		t.Fatal("expected nil Func for nil inputs")
+	}
+}
+
+func TestRateLimit_2(t *testing.T) {
+
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	// The wait for the limiter is reported as the step's wait rather than its execution
+	interval := 30 * time.Millisecond
+	limited := RateLimit(step, rate.NewLimiter(rate.Every(interval), 1))
+
+	var timings []Timing
+	cfg := Config{
+		After: func(step string, timing Timing, err error) {
+			timings = append(timings, timing)
+		},
+	}
+
+	if _, err := NewWithConfig[int](context.Background(), cfg).Then(limited).Then(limited).Finally(f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(timings) != 3 || timings[1].WaitDuration < interval-5*time.Millisecond || timings[1].ExecDuration > interval/2 {
+		t.Fatalf("expected limiter wait to be attributed to WaitDuration, got: %+v", timings)
+	}
+
+	// A limiter that can never allow the call does not fail as if the context was done
+	if _, err := RateLimit(step, rate.NewLimiter(rate.Every(interval), 0))(context.Background()); err == nil || errors.Is(err, ErrContextDone) {
+		t.Fatalf("expected limiter error, got: %v", err)
+	}
+}
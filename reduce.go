@@ -0,0 +1,27 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+)
+
+// Reduce returns a FinalFunc that folds f over the args from left to right, starting from
+// initial.  Should f fail for any arg then the zero value of T is returned, with the error
+// identifying the index of the arg, rather than the partial accumulation.
+func Reduce[T any](initial T, f func(context.Context, T, any) (T, error)) FinalFunc[T] {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, args ...any) (T, error) {
+		acc := initial
+		for i, arg := range args {
+			var err error
+			if acc, err = f(ctx, acc, arg); err != nil {
+				var zero T
+				return zero, fmt.Errorf("arg %d: %w", i, err)
+			}
+		}
+		return acc, nil
+	}
+}
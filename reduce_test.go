@@ -0,0 +1,54 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleReduce() {
+
+	sum := func(ctx context.Context, acc int, v any) (int, error) {
+		return acc + v.(int), nil
+	}
+
+	result, _ := New[int](context.Background(), 1, 2, 3, 4).
+		Finally(Reduce(0, sum))
+
+	fmt.Println("Result:", result)
+	// Output: Result: 10
+}
+
+func TestReduce(t *testing.T) {
+
+	errFailed := errors.New("failed")
+
+	sum := func(ctx context.Context, acc int, v any) (int, error) {
+		if v.(int) < 0 {
+			return acc, errFailed
+		}
+		return acc + v.(int), nil
+	}
+
+	result, err := New[int](context.Background(), 1, 2, -1, 4).
+		Finally(Reduce(100, sum))
+
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("expected underlying error, got: %v", err)
+	}
+	if result != 0 {
+		t.Fatalf("expected partial accumulation not to be returned, got: %v", result)
+	}
+
+	result, err = New[int](context.Background()).
+		Finally(Reduce(100, sum))
+
+	if err != nil || result != 100 {
+		t.Fatalf("expected initial value for no args, got: %v, %v", result, err)
+	}
+
+	if Reduce[int](0, nil) != nil {
+		t.Fatal("expected nil FinalFunc for nil func")
+	}
+}
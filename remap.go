@@ -0,0 +1,102 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrRemap is raised when ThenRemap is unable to map between the structs
+var ErrRemap = errors.New("unable to remap struct")
+
+// ThenRemap adds a step that bridges funcs using different struct shapes.  The single arg
+// must be a struct, or pointer to a struct, and is replaced by a new value of the same type
+// as prototype, with each field named by a key of mapping copied to the field named by its
+// value.  Fields of the new value that are not mapped are left as their zero values.
+// A pointer to a new struct is returned if prototype is a pointer.
+//
+// Mapping is performed using reflection, and so is subject to its limitations: only
+// exported fields can be mapped, embedded fields must be referenced by their promoted
+// names, and each source field must be assignable to its destination field without
+// conversion.  Failures are raised as ErrRemap, identifying the fields concerned, with
+// those arising from prototype and the fields of its type also reported by DryRun.
+func (c Chain[T]) ThenRemap(mapping map[string]string, prototype any) Chain[T] {
+	_, _, err := remapTarget(mapping, prototype)
+
+	return c.queue("remap", err, func(c Chain[T]) Chain[T] {
+		if c.err != nil {
+			return c
+		}
+
+		dstType, isPtr, err := remapTarget(mapping, prototype)
+		if err != nil {
+			return c.fail(err)
+		}
+
+		remap := func(ctx context.Context, args ...any) ([]any, error) {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("expected 1 arg, got %d: %w", len(args), ErrArgCount)
+			}
+
+			src := reflect.ValueOf(args[0])
+			if src.Kind() == reflect.Pointer && !src.IsNil() {
+				src = src.Elem()
+			}
+			if src.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("arg %T is not a struct: %w", args[0], ErrRemap)
+			}
+
+			dst := reflect.New(dstType)
+			for from, to := range mapping {
+				sf, ok := src.Type().FieldByName(from)
+				if !ok || !sf.IsExported() {
+					return nil, fmt.Errorf("no exported field %s in %v: %w", from, src.Type(), ErrRemap)
+				}
+				df, _ := dstType.FieldByName(to)
+				if !sf.Type.AssignableTo(df.Type) {
+					return nil, fmt.Errorf("field %s (%v) cannot be assigned to %s (%v): %w", from, sf.Type, to, df.Type, ErrRemap)
+				}
+
+				// Promoted fields may be reached via nil embedded pointers, so cannot assume success
+				sv, err := src.FieldByIndexErr(sf.Index)
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %w: %w", from, ErrRemap, err)
+				}
+				dv, err := dst.Elem().FieldByIndexErr(df.Index)
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %w: %w", to, ErrRemap, err)
+				}
+				dv.Set(sv)
+			}
+
+			if isPtr {
+				return []any{dst.Interface()}, nil
+			}
+			return []any{dst.Elem().Interface()}, nil
+		}
+
+		return c.then(remap, "remap")
+	})
+}
+
+// remapTarget returns the struct type of prototype, and whether prototype is a pointer to
+// it, checking that each of the fields named by the values of mapping can be set
+func remapTarget(mapping map[string]string, prototype any) (reflect.Type, bool, error) {
+	dstType := reflect.TypeOf(prototype)
+	isPtr := dstType != nil && dstType.Kind() == reflect.Pointer
+	if isPtr {
+		dstType = dstType.Elem()
+	}
+	if dstType == nil || dstType.Kind() != reflect.Struct {
+		return nil, false, fmt.Errorf("prototype %T is not a struct: %w", prototype, ErrRemap)
+	}
+
+	for _, to := range mapping {
+		if df, ok := dstType.FieldByName(to); !ok || !df.IsExported() {
+			return nil, false, fmt.Errorf("no exported field %s in %v: %w", to, dstType, ErrRemap)
+		}
+	}
+
+	return dstType, isPtr, nil
+}
@@ -0,0 +1,128 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type remapUser struct {
+	ID    int
+	Name  string
+	email string
+}
+
+type remapAccount struct {
+	AccountID int
+	Owner     string
+	Balance   float64
+}
+
+func ExampleChain_ThenRemap() {
+
+	lookup := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{remapUser{ID: args[0].(int), Name: "alice"}}, nil
+	}
+
+	describe := func(ctx context.Context, args ...any) (string, error) {
+		a := args[0].(*remapAccount)
+		return fmt.Sprintf("%d:%s", a.AccountID, a.Owner), nil
+	}
+
+	result, _ := New[string](context.Background(), 42).
+		Then(lookup).
+		ThenRemap(map[string]string{"ID": "AccountID", "Name": "Owner"}, &remapAccount{}).
+		Finally(describe)
+
+	fmt.Println("Result:", result)
+	// Output: Result: 42:alice
+}
+
+func TestChain_ThenRemap(t *testing.T) {
+
+	f := func(ctx context.Context, args ...any) (remapAccount, error) {
+		return args[0].(remapAccount), nil
+	}
+
+	result, err := New[remapAccount](context.Background(), &remapUser{ID: 1, Name: "bob"}).
+		ThenRemap(map[string]string{"Name": "Owner"}, remapAccount{}).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if result != (remapAccount{Owner: "bob"}) {
+		t.Fatalf("unexpected result, got: %+v", result)
+	}
+
+	tests := []struct {
+		arg       any
+		mapping   map[string]string
+		prototype any
+		want      error
+	}{
+		{remapUser{}, map[string]string{"Missing": "Owner"}, remapAccount{}, ErrRemap},
+		{remapUser{}, map[string]string{"email": "Owner"}, remapAccount{}, ErrRemap},
+		{remapUser{}, map[string]string{"Name": "Missing"}, remapAccount{}, ErrRemap},
+		{remapUser{}, map[string]string{"Name": "Balance"}, remapAccount{}, ErrRemap},
+		{remapUser{}, map[string]string{}, 42, ErrRemap},
+		{"not a struct", map[string]string{}, remapAccount{}, ErrRemap},
+	}
+
+	for i, test := range tests {
+		_, err := New[remapAccount](context.Background(), test.arg).
+			ThenRemap(test.mapping, test.prototype).
+			Finally(f)
+
+		if !errors.Is(err, test.want) {
+			t.Fatalf("test %d: expected %v, got: %v", i, test.want, err)
+		}
+	}
+
+	_, err = New[remapAccount](context.Background(), remapUser{}, remapUser{}).
+		ThenRemap(map[string]string{}, remapAccount{}).
+		Finally(f)
+
+	if !errors.Is(err, ErrArgCount) {
+		t.Fatalf("expected arg count error, got: %v", err)
+	}
+}
+
+func TestChain_ThenRemap_1(t *testing.T) {
+
+	var calls int
+	lookup := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		return []any{remapUser{ID: 1}}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	tests := []struct {
+		mapping   map[string]string
+		prototype any
+	}{
+		{map[string]string{"ID": "AccountID"}, 42},
+		{map[string]string{"ID": "AccountID"}, nil},
+		{map[string]string{"ID": "Missing"}, remapAccount{}},
+		{map[string]string{"ID": "email"}, remapUser{}},
+	}
+
+	for i, test := range tests {
+		c := New[int](context.Background()).Then(lookup).ThenRemap(test.mapping, test.prototype)
+
+		if err := c.DryRun(f); !errors.Is(err, ErrRemap) {
+			t.Fatalf("test %d: expected DryRun to report the prototype, got: %v", i, err)
+		}
+		if calls != 0 {
+			t.Fatalf("test %d: expected no funcs to be invoked, got: %d", i, calls)
+		}
+		if _, err := c.Finally(f); !errors.Is(err, ErrRemap) {
+			t.Fatalf("test %d: expected remap error, got: %v", i, err)
+		}
+		calls = 0
+	}
+}
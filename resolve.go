@@ -0,0 +1,57 @@
+package chain
+
+import "context"
+
+// OptionResolver resolves named options at execution time, allowing funcs to alter their
+// behaviour per request (e.g. feature flags or tenant settings) without rebuilding the chain
+type OptionResolver interface {
+	Resolve(name string) (any, bool)
+}
+
+// OptionResolverFunc allows an ordinary func to be used as an OptionResolver
+type OptionResolverFunc func(name string) (any, bool)
+
+// Resolve calls f(name)
+func (f OptionResolverFunc) Resolve(name string) (any, bool) {
+	return f(name)
+}
+
+// OptionMap is an OptionResolver backed by a fixed set of values
+type OptionMap map[string]any
+
+// Resolve returns the value held for name, if present
+func (m OptionMap) Resolve(name string) (any, bool) {
+	v, ok := m[name]
+	return v, ok
+}
+
+type optionsKey struct{}
+
+// WithOptions returns a context holding the resolvers, in addition to any already
+// attached to ctx.  Nil resolvers are ignored.
+func WithOptions(ctx context.Context, resolvers ...OptionResolver) context.Context {
+	existing, _ := ctx.Value(optionsKey{}).([]OptionResolver)
+
+	attached := make([]OptionResolver, 0, len(existing)+len(resolvers))
+	attached = append(attached, existing...)
+	for _, r := range resolvers {
+		if r != nil {
+			attached = append(attached, r)
+		}
+	}
+
+	return context.WithValue(ctx, optionsKey{}, attached)
+}
+
+// ResolveOption returns the value of the named option from the resolvers attached to the
+// context.  Resolvers are consulted in the reverse order of attachment, so that the most
+// recently attached resolver able to resolve the name takes precedence.
+func ResolveOption(ctx context.Context, name string) (any, bool) {
+	attached, _ := ctx.Value(optionsKey{}).([]OptionResolver)
+	for i := len(attached) - 1; i >= 0; i-- {
+		if v, ok := attached[i].Resolve(name); ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
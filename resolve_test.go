@@ -0,0 +1,60 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func ExampleWithOptions() {
+
+	scale := func(ctx context.Context, args ...any) ([]any, error) {
+		x := args[0].(int)
+		if v, ok := ResolveOption(ctx, "multiplier"); ok {
+			x *= v.(int)
+		}
+		return []any{x}, nil
+	}
+
+	finally := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	defaults := OptionMap{"multiplier": 2}
+	tenant := OptionMap{"multiplier": 10}
+
+	ctx := WithOptions(context.Background(), defaults)
+
+	r1, _ := Process(ctx, []Func{scale}, finally, 5)
+	r2, _ := Process(WithOptions(ctx, tenant), []Func{scale}, finally, 5)
+
+	fmt.Println("Results:", r1, r2)
+	// Output: Results: 10 50
+}
+
+func TestResolveOption(t *testing.T) {
+
+	ctx := context.Background()
+
+	if _, ok := ResolveOption(ctx, "a"); ok {
+		t.Fatal("expected no option without resolvers")
+	}
+
+	lookups := 0
+	fallback := OptionResolverFunc(func(name string) (any, bool) {
+		lookups++
+		return "fallback", true
+	})
+
+	ctx = WithOptions(ctx, fallback, nil, OptionMap{"a": 1})
+
+	if v, ok := ResolveOption(ctx, "a"); !ok || v != 1 {
+		t.Fatalf("expected most recent resolver to win, got: %v", v)
+	}
+	if lookups != 0 {
+		t.Fatal("expected earlier resolver not to be consulted")
+	}
+	if v, ok := ResolveOption(ctx, "b"); !ok || v != "fallback" {
+		t.Fatalf("expected fallback resolver to be used, got: %v", v)
+	}
+}
@@ -0,0 +1,71 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrResultTypeMismatch is raised by FinallyAsserted when the first arg is not of the
+// chain's result type
+var ErrResultTypeMismatch = errors.New("result type mismatch")
+
+// FinallyAsserted ends the pipeline as Finally, but first checks that the first arg can be
+// asserted to T, failing with ErrResultTypeMismatch if not.  This surfaces a final func
+// that is mistyped relative to the upstream steps as a descriptive error rather than as a
+// panic.  Finally should be preferred where the check is not required.
+func (c Chain[T]) FinallyAsserted(f FinalFunc[T]) (T, error) {
+	var g FinalFunc[T]
+	if f != nil {
+		g = func(ctx context.Context, args ...any) (T, error) {
+			var zero T
+			if len(args) == 0 {
+				return zero, fmt.Errorf("expected %v, got no args: %w", reflect.TypeFor[T](), ErrResultTypeMismatch)
+			}
+			if _, ok := args[0].(T); !ok {
+				return zero, fmt.Errorf("expected %v, got %T: %w", reflect.TypeFor[T](), args[0], ErrResultTypeMismatch)
+			}
+			return f(ctx, args...)
+		}
+	}
+	return c.finally(g, f)
+}
+
+// errorEnvelope is the JSON form of a failed chain, see MarshalResult
+type errorEnvelope struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+	Kind  string `json:"kind"`
+}
+
+// ErrorKind classifies err by the package's sentinel errors, tested via errors.Is(),
+// returning one of "context_done", "panic", "exceeded_retries", "validation" or "other".
+// Where err matches more than one, the first in that order is returned.
+func ErrorKind(err error) string {
+	switch {
+	case errors.Is(err, ErrContextDone):
+		return "context_done"
+	case errors.Is(err, ErrUnhandledPanic):
+		return "panic"
+	case errors.Is(err, ErrExceededRetries):
+		return "exceeded_retries"
+	case errors.Is(err, ErrValidationFailed):
+		return "validation"
+	}
+	return "other"
+}
+
+// MarshalResult returns the outcome of a chain as a uniform JSON envelope, being either
+// {"ok":true,"result":...} or {"ok":false,"error":"...","kind":"..."}, with the kind
+// determined by ErrorKind.  An error is returned only if result cannot be marshalled.
+func MarshalResult[T any](result T, err error) ([]byte, error) {
+	if err != nil {
+		return json.Marshal(errorEnvelope{Error: err.Error(), Kind: ErrorKind(err)})
+	}
+	return json.Marshal(struct {
+		OK     bool `json:"ok"`
+		Result T    `json:"result"`
+	}{OK: true, Result: result})
+}
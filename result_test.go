@@ -0,0 +1,97 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestChain_FinallyAsserted(t *testing.T) {
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	n, err := New[int](context.Background(), 42).FinallyAsserted(f)
+	if err != nil || n != 42 {
+		t.Fatalf("expected typed result, got: %d, %v", n, err)
+	}
+
+	_, err = New[int](context.Background(), "42").FinallyAsserted(f)
+	if !errors.Is(err, ErrResultTypeMismatch) || errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected result type mismatch, got: %v", err)
+	}
+	if want := "error in step 0 (" + runtimeFuncName(f) + "): expected int, got string: result type mismatch"; err.Error() != want {
+		t.Fatalf("expected %q, got: %v", want, err)
+	}
+
+	_, err = New[int](context.Background()).FinallyAsserted(f)
+	if !errors.Is(err, ErrResultTypeMismatch) {
+		t.Fatalf("expected result type mismatch for no args, got: %v", err)
+	}
+
+	_, err = New[int](context.Background(), 1).FinallyAsserted(nil)
+	if !errors.Is(err, ErrNilFinalFunc) {
+		t.Fatalf("expected nil final func error, got: %v", err)
+	}
+
+	// Without the check, the mistyped final func panics
+	_, err = New[int](context.Background(), "42").Finally(f)
+	if !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected unhandled panic, got: %v", err)
+	}
+}
+
+func ExampleMarshalResult() {
+
+	ok, _ := MarshalResult(map[string]int{"total": 42}, nil)
+	fmt.Println(string(ok))
+
+	failed, _ := MarshalResult(0, fmt.Errorf("charge: %w", ErrExceededRetries))
+	fmt.Println(string(failed))
+	// Output:
+	// {"ok":true,"result":{"total":42}}
+	// {"ok":false,"error":"charge: exceeded retry count","kind":"exceeded_retries"}
+}
+
+func TestMarshalResult(t *testing.T) {
+
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{fmt.Errorf("prior to call: %w", ErrContextDone), "context_done"},
+		{&PanicError{Value: "Boom!"}, "panic"},
+		{fmt.Errorf("%w: failed", ErrExceededRetries), "exceeded_retries"},
+		{fmt.Errorf("rule 0, %w: too short", ErrValidationFailed), "validation"},
+		{errors.New("failed"), "other"},
+		// Context done takes precedence over exceeded retries
+		{fmt.Errorf("%w: %w", ErrExceededRetries, ErrContextDone), "context_done"},
+	}
+
+	for _, test := range tests {
+		data, err := MarshalResult("unused", test.err)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var got map[string]any
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("invalid JSON %s: %v", data, err)
+		}
+		if len(got) != 3 || got["ok"] != false || got["error"] != test.err.Error() || got["kind"] != test.want {
+			t.Fatalf("expected kind %s for %v, got: %s", test.want, test.err, data)
+		}
+	}
+
+	data, err := MarshalResult([]int{1, 2}, nil)
+	if err != nil || string(data) != `{"ok":true,"result":[1,2]}` {
+		t.Fatalf("unexpected success payload, got: %s, %v", data, err)
+	}
+
+	if _, err := MarshalResult(func() {}, nil); err == nil {
+		t.Fatal("expected error for result that cannot be marshalled")
+	}
+}
@@ -0,0 +1,89 @@
+package chain
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// ErrInvalidWeights is raised by ThenRoundRobin if the weights do not match the funcs,
+// are negative, or are all zero
+var ErrInvalidWeights = errors.New("invalid weights")
+
+// ThenRoundRobin adds a transformation step that distributes load across equivalent
+// implementations.  Each invocation of the step, including retries, chooses one of the funcs
+// at random in proportion to its weight, with the choice reported to Config.OnChoice.  The
+// choice uses the chain's Retry.Rand if set, allowing the choices to be reproduced, and
+// otherwise the global math/rand source.  Invalid weights are reported by DryRun.
+func (c Chain[T]) ThenRoundRobin(weights []int, fs ...Func) Chain[T] {
+	const name = "round robin"
+
+	return c.queue(name, cmp.Or(check(ErrNilThenFunc, fs...), checkWeights(weights, len(fs))), func(c Chain[T]) Chain[T] {
+		if c.err != nil {
+			return c
+		}
+		if slices.ContainsFunc(fs, func(f Func) bool { return f == nil }) {
+			return c.fail(ErrNilThenFunc)
+		}
+		if err := checkWeights(weights, len(fs)); err != nil {
+			return c.fail(err)
+		}
+
+		total := 0
+		for _, w := range weights {
+			total += w
+		}
+
+		intn := rand.Intn
+		if c.cfg.Retry.Rand != nil {
+			intn = c.cfg.Retry.Rand.Intn
+		}
+
+		g := func(ctx context.Context, args ...any) ([]any, error) {
+			choice := 0
+			for n := intn(total); n >= weights[choice]; choice++ {
+				n -= weights[choice]
+			}
+
+			if c.cfg.OnChoice != nil {
+				c.cfg.OnChoice(name, choice)
+			}
+
+			result, err := fs[choice](ctx, args...)
+			if err != nil {
+				return nil, fmt.Errorf("error in %s: %w", c.cfg.funcName(fs[choice]), err)
+			}
+			return result, nil
+		}
+
+		return c.then(g, name)
+	})
+}
+
+// ThenWeighted is ThenRoundRobin, named for how the funcs are chosen
+func (c Chain[T]) ThenWeighted(weights []int, fs ...Func) Chain[T] {
+	return c.ThenRoundRobin(weights, fs...)
+}
+
+// checkWeights returns an error wrapping ErrInvalidWeights if weights cannot be used to
+// choose between n funcs
+func checkWeights(weights []int, n int) error {
+	if len(weights) != n {
+		return fmt.Errorf("%d weights for %d funcs: %w", len(weights), n, ErrInvalidWeights)
+	}
+
+	total := 0
+	for _, w := range weights {
+		if w < 0 {
+			return fmt.Errorf("negative weight %d: %w", w, ErrInvalidWeights)
+		}
+		total += w
+	}
+	if total == 0 {
+		return fmt.Errorf("no positive weights: %w", ErrInvalidWeights)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestChain_ThenRoundRobin(t *testing.T) {
+
+	backend := func(id int) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			return []any{id}, nil
+		}
+	}
+
+	counts := map[int]int{}
+	cfg := Config{
+		OnChoice: func(step string, choice int) {
+			counts[choice]++
+		},
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	const runs = 1000
+	for range runs {
+		result, err := NewWithConfig[int](context.Background(), cfg).
+			ThenRoundRobin([]int{3, 0, 1}, backend(0), backend(1), backend(2)).
+			Finally(f)
+
+		if err != nil {
+			t.Fatalf("unexpected error, got: %v", err)
+		}
+		if counts[result] == 0 {
+			t.Fatalf("result %d does not match the choice recorded", result)
+		}
+	}
+
+	if counts[1] != 0 {
+		t.Fatalf("zero weighted func was chosen %d times", counts[1])
+	}
+	if counts[0] < counts[2] || counts[0]+counts[2] != runs {
+		t.Fatalf("unexpected distribution, got: %v", counts)
+	}
+}
+
+func TestChain_ThenRoundRobin_1(t *testing.T) {
+
+	pass := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	tests := []struct {
+		weights []int
+		fs      []Func
+		want    error
+	}{
+		{[]int{1}, []Func{pass, pass}, ErrInvalidWeights},
+		{[]int{1, -1}, []Func{pass, pass}, ErrInvalidWeights},
+		{[]int{0, 0}, []Func{pass, pass}, ErrInvalidWeights},
+		{[]int{1, 1}, []Func{pass, nil}, ErrNilThenFunc},
+	}
+
+	for i, test := range tests {
+		_, err := New[int](context.Background()).
+			ThenRoundRobin(test.weights, test.fs...).
+			Finally(f)
+
+		if !errors.Is(err, test.want) {
+			t.Fatalf("test %d: expected %v, got: %v", i, test.want, err)
+		}
+	}
+}
+
+func TestChain_ThenRoundRobin_2(t *testing.T) {
+
+	backend := func(id int) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			return []any{id}, nil
+		}
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	// The choices are reproducible from the chain's source of randomness
+	choices := func(seed int64, weighted bool) []int {
+		cfg := Config{Retry: Retry{Rand: rand.New(rand.NewSource(seed))}}
+
+		var out []int
+		for range 20 {
+			c := NewWithConfig[int](context.Background(), cfg)
+			if weighted {
+				c = c.ThenWeighted([]int{1, 1, 1}, backend(0), backend(1), backend(2))
+			} else {
+				c = c.ThenRoundRobin([]int{1, 1, 1}, backend(0), backend(1), backend(2))
+			}
+			result, err := c.Finally(f)
+			if err != nil {
+				t.Fatalf("unexpected error, got: %v", err)
+			}
+			out = append(out, result)
+		}
+		return out
+	}
+
+	if a, b := choices(1, false), choices(1, false); !slices.Equal(a, b) {
+		t.Fatalf("expected reproducible choices, got: %v and %v", a, b)
+	}
+	if a, b := choices(1, false), choices(1, true); !slices.Equal(a, b) {
+		t.Fatalf("expected ThenWeighted to choose as ThenRoundRobin, got: %v and %v", a, b)
+	}
+
+	// Invalid weights are reported without executing the chain
+	err := New[int](context.Background()).
+		ThenRoundRobin([]int{1}, backend(0), backend(1)).
+		DryRun(f)
+
+	if !errors.Is(err, ErrInvalidWeights) {
+		t.Fatalf("expected DryRun to report invalid weights, got: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// ThenSegment adds a group of funcs that are run in sequence as a single unit.  Should any
+// func in the segment fail, the whole segment is retried from its entry args according to
+// the retry policy provided, which applies in place of the chain's policy.  Each attempt
+// receives a fresh copy of the entry args slice, however the values themselves are shared,
+// so funcs must not mutate values referenced by their args if the segment is to be retried
+// cleanly.
+func (c Chain[T]) ThenSegment(retry Retry, fs ...Func) Chain[T] {
+	return c.queue("segment", check(ErrNilThenFunc, fs...), func(c Chain[T]) Chain[T] {
+		if c.err != nil {
+			return c
+		}
+		if slices.ContainsFunc(fs, func(f Func) bool { return f == nil }) {
+			return c.fail(ErrNilThenFunc)
+		}
+
+		segment := func(ctx context.Context, args ...any) ([]any, error) {
+			args = slices.Clone(args)
+			for _, f := range fs {
+				select {
+				case <-ctx.Done():
+					return nil, errContextDone(ctx, c.cfg.funcName(f))
+				default:
+				}
+
+				var err error
+				if args, err = f(ctx, args...); err != nil {
+					return nil, fmt.Errorf("error in %s: %w", c.cfg.funcName(f), err)
+				}
+			}
+			return args, nil
+		}
+
+		return c.thenWithRetry(segment, "segment", retry.ensureValid())
+	})
+}
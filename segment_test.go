@@ -0,0 +1,99 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChain_ThenSegment(t *testing.T) {
+
+	var calls1, calls2 int
+
+	// Mutates its args in place, which must not leak into a retried segment
+	f1 := func(ctx context.Context, args ...any) ([]any, error) {
+		calls1++
+		args[0] = args[0].(int) + 1
+		return args, nil
+	}
+
+	// Fails on the first attempt only
+	f2 := func(ctx context.Context, args ...any) ([]any, error) {
+		calls2++
+		if calls2 == 1 {
+			return nil, errors.New("transient")
+		}
+		return []any{args[0].(int) * 10}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	result, err := New[int](context.Background(), 5).
+		ThenSegment(Retry{NumRetries: 2, BaseWait: time.Millisecond}, f1, f2).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if result != 60 {
+		t.Fatalf("unexpected result.  wanted: 60, got: %v", result)
+	}
+	if calls1 != 2 || calls2 != 2 {
+		t.Fatalf("expected whole segment to be retried, got calls: %d, %d", calls1, calls2)
+	}
+}
+
+func TestChain_ThenSegment_1(t *testing.T) {
+
+	errFailed := errors.New("failed")
+
+	calls := 0
+	fail := func(ctx context.Context, args ...any) ([]any, error) {
+		calls++
+		return nil, errFailed
+	}
+
+	pass := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	_, err := New[int](context.Background(), 5).
+		ThenSegment(Retry{NumRetries: 1, BaseWait: time.Millisecond}, fail).
+		Finally(f)
+
+	if !errors.Is(err, ErrExceededRetries) {
+		t.Fatalf("expected exceeded retries error, got: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("unexpected number of calls.  wanted: 2, got: %d", calls)
+	}
+
+	// Segment retries must not leak into the chain's own policy
+	calls = 0
+	_, err = New[int](context.Background(), 5).
+		ThenSegment(Retry{NumRetries: 1, BaseWait: time.Millisecond}, pass).
+		Then(fail).
+		Finally(f)
+
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("expected underlying error, got: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("unexpected number of calls.  wanted: 1, got: %d", calls)
+	}
+
+	_, err = New[int](context.Background(), 5).
+		ThenSegment(Retry{}, fail, nil).
+		Finally(f)
+
+	if !errors.Is(err, ErrNilThenFunc) {
+		t.Fatalf("expected NilThen error, got: %v", err)
+	}
+}
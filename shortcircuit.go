@@ -0,0 +1,10 @@
+package chain
+
+import "errors"
+
+// ErrShortCircuit may be returned by the func of a step, together with its outputs, to
+// indicate that the answer has been determined early.  The remaining steps of the chain are
+// skipped, and the outputs passed directly to the final func.  The step is regarded as
+// successful, so is not retried, and ErrShortCircuit is not returned by the chain.  It may
+// be wrapped, as it is tested via errors.Is().
+var ErrShortCircuit = errors.New("short circuit")
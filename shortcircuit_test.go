@@ -0,0 +1,86 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleErrShortCircuit() {
+
+	cached := func(ctx context.Context, args ...any) ([]any, error) {
+		if args[0] == "known" {
+			return []any{"from cache"}, ErrShortCircuit
+		}
+		return args, nil
+	}
+
+	lookup := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{"from lookup"}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		return args[0].(string), nil
+	}
+
+	a, _ := New[string](context.Background(), "known").Then(cached).Then(lookup).Finally(f)
+	b, _ := New[string](context.Background(), "unknown").Then(cached).Then(lookup).Finally(f)
+
+	fmt.Println(a)
+	fmt.Println(b)
+	// Output:
+	// from cache
+	// from lookup
+}
+
+func TestErrShortCircuit(t *testing.T) {
+
+	var calls []int
+	step := func(i int, err error) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			calls = append(calls, i)
+			return []any{i}, err
+		}
+	}
+
+	var attempts int
+	short := func(ctx context.Context, args ...any) ([]any, error) {
+		attempts++
+		calls = append(calls, 2)
+		return []any{"answer"}, fmt.Errorf("done early: %w", ErrShortCircuit)
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		return fmt.Sprint(args...), nil
+	}
+
+	stats := NewStatsCollector()
+	cfg := Config{Retry: Retry{NumRetries: 2, BaseWait: 1}, Stats: stats}
+
+	s, err := NewWithConfig[string](context.Background(), cfg).
+		Then(step(1, nil)).
+		Then(short).
+		Then(step(3, nil)).
+		Carry("k", "v").
+		Then(step(4, nil)).
+		Finally(f)
+
+	if err != nil || s != "answer" {
+		t.Fatalf("expected final func to run on short-circuited args, got: %q, %v", s, err)
+	}
+	if fmt.Sprint(calls) != "[1 2]" || attempts != 1 {
+		t.Fatalf("expected remaining steps to be skipped without retry, got: %v, %d", calls, attempts)
+	}
+	if st := stats.Snapshot()[runtimeFuncName(short)]; st.Successes != 1 || st.Failures != 0 {
+		t.Fatalf("expected short-circuit to be recorded as success, got: %+v", st)
+	}
+
+	// Other errors are unaffected
+	errFailed := errors.New("failed")
+	calls = nil
+	_, err = New[string](context.Background()).Then(step(1, errFailed)).Then(step(2, nil)).Finally(f)
+	if !errors.Is(err, errFailed) || fmt.Sprint(calls) != "[1]" {
+		t.Fatalf("expected step error, got: %v, %v", err, calls)
+	}
+}
@@ -0,0 +1,22 @@
+package chain
+
+import "context"
+
+// Spread adds a step that flattens a single arg holding a []any into the args, so that a
+// step returning its outputs nested as one slice composes with steps expecting them as
+// separate args.  Args are forwarded unchanged if there is more or less than one, or the
+// single arg is not a []any.
+func (c Chain[T]) Spread() Chain[T] {
+	return c.queue("spread", nil, func(c Chain[T]) Chain[T] {
+		spread := func(ctx context.Context, args ...any) ([]any, error) {
+			if len(args) == 1 {
+				if inner, ok := args[0].([]any); ok {
+					return inner, nil
+				}
+			}
+			return args, nil
+		}
+
+		return c.thenWithRetry(spread, "spread", Retry{})
+	})
+}
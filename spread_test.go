@@ -0,0 +1,53 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func ExampleChain_Spread() {
+
+	pair := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{[]any{"a", "b"}}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return len(args), nil
+	}
+
+	n, _ := New[int](context.Background()).
+		Then(pair).
+		Spread().
+		Finally(f)
+
+	fmt.Println("Args:", n)
+	// Output: Args: 2
+}
+
+func TestChain_Spread(t *testing.T) {
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		return fmt.Sprintf("%d %v", len(args), args), nil
+	}
+
+	tests := []struct {
+		name string
+		args []any
+		want string
+	}{
+		{"flatten", []any{[]any{1, 2, 3}}, "3 [1 2 3]"},
+		{"multiple args", []any{[]any{1, 2}, 3}, "2 [[1 2] 3]"},
+		{"non-slice", []any{1}, "1 [1]"},
+		{"typed slice", []any{[]int{1, 2}}, "1 [[1 2]]"},
+		{"nested once", []any{[]any{[]any{1, 2}}}, "1 [[1 2]]"},
+		{"no args", nil, "0 []"},
+	}
+
+	for _, test := range tests {
+		s, err := New[string](context.Background(), test.args...).Spread().Finally(f)
+		if err != nil || s != test.want {
+			t.Fatalf("%s: expected %q, got: %q, %v", test.name, test.want, s, err)
+		}
+	}
+}
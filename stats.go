@@ -0,0 +1,87 @@
+package chain
+
+import (
+	"errors"
+	"maps"
+	"sync"
+)
+
+// StepStats holds the retry statistics accumulated for a func
+type StepStats struct {
+	// Attempts is the number of times the func was invoked by a chain, including retries
+	Attempts int64
+	// Retries is the number of attempts beyond the first
+	Retries int64
+	// Successes is the number of times the func completed without error
+	Successes int64
+	// SuccessesAfterRetry is the number of Successes that required at least one retry
+	SuccessesAfterRetry int64
+	// Failures is the number of times the func failed, including Exhaustions
+	Failures int64
+	// Exhaustions is the number of times the func failed with ErrExceededRetries
+	Exhaustions int64
+}
+
+// StatsCollector accumulates retry statistics across many chain invocations, keyed by
+// the name of each func.  It is safe for concurrent use, so a single long-lived collector
+// can be shared by all chains via Config.Stats.  The zero value is ready for use.
+type StatsCollector struct {
+	lck   sync.Mutex
+	stats map[string]StepStats
+}
+
+// NewStatsCollector returns an empty StatsCollector
+func NewStatsCollector() *StatsCollector {
+	return &StatsCollector{stats: map[string]StepStats{}}
+}
+
+// Snapshot returns a copy of the statistics accumulated so far
+func (s *StatsCollector) Snapshot() map[string]StepStats {
+	s.lck.Lock()
+	defer s.lck.Unlock()
+
+	if s.stats == nil {
+		return map[string]StepStats{}
+	}
+	return maps.Clone(s.stats)
+}
+
+// Reset discards the statistics accumulated so far
+func (s *StatsCollector) Reset() {
+	s.lck.Lock()
+	defer s.lck.Unlock()
+
+	s.stats = map[string]StepStats{}
+}
+
+func (s *StatsCollector) record(named any, attempts int, err error) {
+	if s == nil {
+		return
+	}
+
+	name := nameOf(named)
+
+	s.lck.Lock()
+	defer s.lck.Unlock()
+
+	if s.stats == nil {
+		s.stats = map[string]StepStats{}
+	}
+
+	stats := s.stats[name]
+	stats.Attempts += int64(attempts)
+	stats.Retries += int64(attempts - 1)
+	switch {
+	case err == nil:
+		stats.Successes++
+		if attempts > 1 {
+			stats.SuccessesAfterRetry++
+		}
+	case errors.Is(err, ErrExceededRetries):
+		stats.Failures++
+		stats.Exhaustions++
+	default:
+		stats.Failures++
+	}
+	s.stats[name] = stats
+}
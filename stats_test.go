@@ -0,0 +1,99 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func succeedOnSecond(n *int) Func {
+	return func(ctx context.Context, args ...any) ([]any, error) {
+		*n++
+		if *n%2 == 1 {
+			return nil, errors.New("transient")
+		}
+		return args, nil
+	}
+}
+
+func alwaysFail(ctx context.Context, args ...any) ([]any, error) {
+	return nil, errors.New("permanent")
+}
+
+func TestStatsCollector(t *testing.T) {
+
+	stats := NewStatsCollector()
+
+	cfg := Config{
+		Retry: Retry{NumRetries: 2, BaseWait: time.Millisecond},
+		Stats: stats,
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	var n int
+	flaky := succeedOnSecond(&n)
+
+	for range 3 {
+		if _, err := NewWithConfig[int](context.Background(), cfg).Then(flaky).Finally(f); err != nil {
+			t.Fatalf("unexpected error, got: %v", err)
+		}
+	}
+
+	if _, err := NewWithConfig[int](context.Background(), cfg).Then(alwaysFail).Finally(f); !errors.Is(err, ErrExceededRetries) {
+		t.Fatalf("expected exceeded retries error, got: %v", err)
+	}
+
+	// Collectors are shared across concurrent chains
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = NewWithConfig[int](context.Background(), cfg).Finally(f)
+		}()
+	}
+	wg.Wait()
+
+	snapshot := stats.Snapshot()
+
+	if got, want := snapshot[runtimeFuncName(flaky)], (StepStats{Attempts: 6, Retries: 3, Successes: 3, SuccessesAfterRetry: 3}); got != want {
+		t.Fatalf("unexpected flaky stats.  wanted: %+v, got: %+v", want, got)
+	}
+	if got, want := snapshot[runtimeFuncName(alwaysFail)], (StepStats{Attempts: 3, Retries: 2, Failures: 1, Exhaustions: 1}); got != want {
+		t.Fatalf("unexpected failure stats.  wanted: %+v, got: %+v", want, got)
+	}
+	if got, want := snapshot[runtimeFuncName(f)], (StepStats{Attempts: 13, Successes: 13}); got != want {
+		t.Fatalf("unexpected final stats.  wanted: %+v, got: %+v", want, got)
+	}
+
+	stats.Reset()
+	if len(stats.Snapshot()) != 0 {
+		t.Fatal("expected no stats after reset")
+	}
+}
+
+func TestStatsCollector_1(t *testing.T) {
+
+	stats := &StatsCollector{}
+
+	if snapshot := stats.Snapshot(); snapshot == nil || len(snapshot) != 0 {
+		t.Fatalf("expected empty snapshot, got: %v", snapshot)
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	if _, err := NewWithConfig[int](context.Background(), Config{Stats: stats}).Finally(f); err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+
+	if got, want := stats.Snapshot()[runtimeFuncName(f)], (StepStats{Attempts: 1, Successes: 1}); got != want {
+		t.Fatalf("unexpected stats from zero value collector.  wanted: %+v, got: %+v", want, got)
+	}
+}
@@ -0,0 +1,62 @@
+package chain
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// StepConfig overrides the behaviour of a step added via ThenNamed
+type StepConfig struct {
+	// Timeout, if > 0, limits the duration of each attempt of the step, with the context
+	// passed to the func being cancelled on expiry
+	Timeout time.Duration
+	// Retry, if not nil, replaces the chain's retry policy for the step
+	Retry *Retry
+}
+
+type stepConfigKey struct{}
+
+// WithStepConfig returns a context carrying step overrides keyed by step name, allowing
+// individual steps to be tuned (for example per request or per tenant) without changing
+// the pipeline definition.  Overrides apply to steps added via ThenNamed to chains using
+// the returned context, and take precedence over the chain's Config.  Calling
+// WithStepConfig again replaces any overrides already carried by the context.
+func WithStepConfig(ctx context.Context, steps map[string]StepConfig) context.Context {
+	return context.WithValue(ctx, stepConfigKey{}, steps)
+}
+
+// stepConfig returns the overrides for the named step, if any
+func stepConfig(ctx context.Context, name string) (StepConfig, bool) {
+	steps, _ := ctx.Value(stepConfigKey{}).(map[string]StepConfig)
+	sc, ok := steps[name]
+	return sc, ok
+}
+
+// thenStep invokes f as the named step, applying any overrides carried by the chain's context
+func (c Chain[T]) thenStep(f Func, name string) Chain[T] {
+	sc, ok := stepConfig(c.ctx, name)
+	if !ok || f == nil {
+		return c.then(f, name)
+	}
+
+	retry := c.cfg.Retry
+	if sc.Retry != nil {
+		var warnings []string
+		retry, warnings = sc.Retry.Validate()
+		for _, warning := range warnings {
+			c.cfg.warn(c.ctx, "step retry override adjusted", slog.String("step", name), slog.String("adjustment", warning))
+		}
+	}
+
+	g := f
+	if sc.Timeout > 0 {
+		g = func(ctx context.Context, args ...any) ([]any, error) {
+			ctx, cancel := context.WithTimeout(ctx, sc.Timeout)
+			defer cancel()
+			return f(ctx, args...)
+		}
+	}
+
+	return c.thenWithRetry(g, name, retry)
+}
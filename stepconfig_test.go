@@ -0,0 +1,83 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithStepConfig(t *testing.T) {
+
+	var attempts int
+	flaky := func(ctx context.Context, args ...any) ([]any, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New("transient")
+		}
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	ctx := WithStepConfig(context.Background(), map[string]StepConfig{
+		"fetch": {Retry: &Retry{NumRetries: 2, BaseWait: time.Millisecond}},
+	})
+
+	result, err := New[int](ctx, 5).
+		ThenNamed("fetch", flaky).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("expected override to allow retries, got: %v", err)
+	}
+	if result != 5 || attempts != 3 {
+		t.Fatalf("unexpected result, got: %v after %d attempts", result, attempts)
+	}
+
+	// Overrides are keyed by name, so other steps are unaffected
+	attempts = 0
+	_, err = New[int](ctx, 5).
+		ThenNamed("other", flaky).
+		Finally(f)
+
+	if err == nil || attempts != 1 {
+		t.Fatalf("expected no retries, got: %v after %d attempts", err, attempts)
+	}
+}
+
+func TestWithStepConfig_1(t *testing.T) {
+
+	slow := func(ctx context.Context, args ...any) ([]any, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return args, nil
+		}
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	ctx := WithStepConfig(context.Background(), map[string]StepConfig{
+		"slow": {Timeout: 5 * time.Millisecond},
+	})
+
+	start := time.Now()
+
+	// The context override takes precedence over the chain's retry policy
+	_, err := NewWithRetries[int](ctx, Retry{NumRetries: 0}, 5).
+		ThenNamed("slow", slow).
+		Finally(f)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded error, got: %v", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Fatalf("timeout override not applied, took: %v", d)
+	}
+}
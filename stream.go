@@ -0,0 +1,40 @@
+package chain
+
+import "context"
+
+// Stream runs the chain of fs and fn over each set of args received from in, delivering the
+// outcome of each chain as a Result on the returned channel, in the order the args were
+// received.  Chains are run one at a time, so a slow consumer applies backpressure to in.
+// The returned channel is closed once in is closed and all its args have been processed,
+// or once the context is done, in which case any outcome not yet delivered is dropped.
+func Stream[T any](ctx context.Context, in <-chan []any, fs []Func, fn FinalFunc[T]) <-chan Result[T] {
+	out := make(chan Result[T])
+
+	go func() {
+		defer close(out)
+
+		for {
+			var args []any
+			var ok bool
+
+			select {
+			case <-ctx.Done():
+				return
+			case args, ok = <-in:
+				if !ok {
+					return
+				}
+			}
+
+			v, err := Process(ctx, fs, fn, args...)
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- Result[T]{Value: v, Err: err}:
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,105 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func ExampleStream() {
+
+	double := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0].(int) * 2}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	in := make(chan []any)
+	go func() {
+		defer close(in)
+		for i := range 3 {
+			in <- []any{i}
+		}
+	}()
+
+	for r := range Stream(context.Background(), in, []Func{double}, f) {
+		fmt.Println(r.Value, r.Err)
+	}
+	// Output:
+	// 0 <nil>
+	// 2 <nil>
+	// 4 <nil>
+}
+
+func TestStream(t *testing.T) {
+
+	errOdd := errors.New("odd")
+
+	even := func(ctx context.Context, args ...any) ([]any, error) {
+		if args[0].(int)%2 != 0 {
+			return nil, errOdd
+		}
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int) * 10, nil
+	}
+
+	in := make(chan []any, 5)
+	for i := range 5 {
+		in <- []any{i}
+	}
+	close(in)
+
+	var values []int
+	var failed int
+	for r := range Stream(context.Background(), in, []Func{even}, f) {
+		if r.Err != nil {
+			if !errors.Is(r.Err, errOdd) {
+				t.Fatalf("unexpected error: %v", r.Err)
+			}
+			failed++
+			values = append(values, -1)
+			continue
+		}
+		values = append(values, r.Value)
+	}
+
+	if fmt.Sprint(values) != "[0 -1 20 -1 40]" || failed != 2 {
+		t.Fatalf("unexpected output sequence, got: %v", values)
+	}
+}
+
+func TestStream_1(t *testing.T) {
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// in is never closed, so the output is only closed by cancellation
+	in := make(chan []any)
+	out := Stream(ctx, in, nil, f)
+
+	in <- []any{1}
+	if r := <-out; r.Err != nil || r.Value != 1 {
+		t.Fatalf("unexpected result, got: %v", r)
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-out:
+		if ok {
+			t.Fatal("expected no further results once cancelled")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected output to be closed once cancelled")
+	}
+}
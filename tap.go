@@ -0,0 +1,25 @@
+package chain
+
+import (
+	"context"
+	"slices"
+)
+
+// Tap adds a step that observes the args, for example to log or emit metrics, forwarding
+// them unchanged.  f receives a copy of the args, so cannot alter those passed downstream.
+// Unlike Do, the chain's retry policy does not apply, so an error from f immediately fails
+// the chain.
+func (c Chain[T]) Tap(f func(ctx context.Context, args ...any) error) Chain[T] {
+	return c.queue(f, check(ErrNilThenFunc, f), func(c Chain[T]) Chain[T] {
+		var g Func
+		if f != nil {
+			g = func(ctx context.Context, args ...any) ([]any, error) {
+				if err := f(ctx, slices.Clone(args)...); err != nil {
+					return nil, err
+				}
+				return args, nil
+			}
+		}
+		return c.thenWithRetry(g, f, Retry{})
+	})
+}
@@ -0,0 +1,93 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestChain_Tap(t *testing.T) {
+
+	var seen []any
+	tap := func(ctx context.Context, args ...any) error {
+		seen = append(seen, args...)
+		args[0] = "overwritten"
+		return nil
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		return fmt.Sprint(args...), nil
+	}
+
+	result, err := New[string](context.Background(), "a", 1).
+		Tap(tap).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if result != "a1" {
+		t.Fatalf("expected args to pass through untouched, got: %v", result)
+	}
+	if fmt.Sprint(seen...) != "a1" {
+		t.Fatalf("expected tap to observe args, got: %v", seen)
+	}
+}
+
+func TestChain_Tap_1(t *testing.T) {
+
+	errFailed := errors.New("failed")
+
+	var taps int
+	fail := func(ctx context.Context, args ...any) error {
+		taps++
+		return errFailed
+	}
+
+	var called bool
+	next := func(ctx context.Context, args ...any) ([]any, error) {
+		called = true
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := NewWithRetries[int](context.Background(), Retry{NumRetries: 3, BaseWait: time.Millisecond}).
+		Tap(fail).
+		Then(next).
+		Finally(f)
+
+	if !errors.Is(err, errFailed) || called {
+		t.Fatalf("expected failing tap to abort the chain, got: %v", err)
+	}
+	if taps != 1 {
+		t.Fatalf("expected tap not to be retried, got: %d", taps)
+	}
+
+	boom := func(ctx context.Context, args ...any) error {
+		panic("Tap Boom!")
+	}
+
+	_, err = New[int](context.Background()).
+		Tap(boom).
+		Finally(f)
+
+	if !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected caught panic error, got: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = New[int](ctx).
+		Tap(fail).
+		Finally(f)
+
+	if !errors.Is(err, ErrContextDone) {
+		t.Fatalf("expected context done error, got: %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package chain
+
+import (
+	"cmp"
+	"context"
+	"errors"
+)
+
+// ThenThenMap adds a transformation step that invokes f and then reshapes its output with
+// post, avoiding a separate Then for trivial reshaping.  post must be a pure func of the
+// output of f.  It is applied within the same step as f, including to the outputs of an f
+// that short-circuits the chain, and should it panic then the step fails without retry.
+func (c Chain[T]) ThenThenMap(f Func, post func([]any) []any) Chain[T] {
+	return c.queue(f, cmp.Or(check(ErrNilThenFunc, f), check(ErrNilThenFunc, post)), func(c Chain[T]) Chain[T] {
+		var g Func
+		if f != nil && post != nil {
+			g = func(ctx context.Context, args ...any) ([]any, error) {
+				result, err := f(ctx, args...)
+				if err != nil && !errors.Is(err, ErrShortCircuit) {
+					return nil, err
+				}
+				return post(result), err
+			}
+		}
+		return c.then(g, f)
+	})
+}
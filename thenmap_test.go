@@ -0,0 +1,104 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleChain_ThenThenMap() {
+
+	split := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{strings.Split(args[0].(string), ",")}, nil
+	}
+
+	spread := func(args []any) []any {
+		out := []any{}
+		for _, s := range args[0].([]string) {
+			out = append(out, s)
+		}
+		return out
+	}
+
+	count := func(ctx context.Context, args ...any) (int, error) {
+		return len(args), nil
+	}
+
+	result, _ := New[int](context.Background(), "a,b,c").
+		ThenThenMap(split, spread).
+		Finally(count)
+
+	fmt.Println("Result:", result)
+	// Output: Result: 3
+}
+
+func TestChain_ThenThenMap(t *testing.T) {
+
+	pass := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	boom := func(args []any) []any {
+		panic("Post Boom!")
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := New[int](context.Background(), 5).
+		ThenThenMap(pass, boom).
+		Finally(f)
+
+	if !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected caught panic error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "step 0 (") {
+		t.Fatalf("expected panic to be attributed to the step, got: %v", err)
+	}
+
+	_, err = New[int](context.Background(), 5).
+		ThenThenMap(pass, nil).
+		Finally(f)
+
+	if !errors.Is(err, ErrNilThenFunc) {
+		t.Fatalf("expected nil func error, got: %v", err)
+	}
+}
+
+func TestChain_ThenThenMap_1(t *testing.T) {
+
+	early := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0], args[0]}, ErrShortCircuit
+	}
+
+	sum := func(args []any) []any {
+		return []any{args[0].(int) + args[1].(int)}
+	}
+
+	var later bool
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		later = true
+		return args, nil
+	}
+
+	obs := &recordingObserver{}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	result, err := NewWithConfig[int](context.Background(), Config{Observer: obs}, 21).
+		ThenThenMap(early, sum).
+		Then(step).
+		Finally(f)
+
+	if err != nil || result != 42 || later {
+		t.Fatalf("expected short circuit with reshaped outputs, got: %d, %v, later ran: %v", result, err, later)
+	}
+	if len(obs.events) != 4 {
+		t.Fatalf("expected a single step before the final func, got: %v", obs.events)
+	}
+}
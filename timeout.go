@@ -0,0 +1,28 @@
+package chain
+
+import (
+	"context"
+	"time"
+)
+
+// ThenWithTimeout adds a transformation step that must complete within d, including any
+// retries, in addition to the deadline of the chain's context.  The context passed to f is
+// cancelled once d has elapsed, and f must observe it; subsequent steps are unaffected.
+// A d <= 0 disables the step's deadline, leaving only that of the chain's context.
+func (c Chain[T]) ThenWithTimeout(f Func, d time.Duration) Chain[T] {
+	return c.queue(f, check(ErrNilThenFunc, f), func(c Chain[T]) Chain[T] {
+		if c.err != nil || d <= 0 {
+			return c.then(f, f)
+		}
+
+		ctx, cancel := context.WithTimeout(c.ctx, d)
+		defer cancel()
+
+		step := c
+		step.ctx = ctx
+
+		out := step.then(f, f)
+		out.ctx = c.ctx
+		return out
+	})
+}
@@ -0,0 +1,105 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChain_ThenWithTimeout(t *testing.T) {
+
+	wait := func(d time.Duration) Func {
+		return func(ctx context.Context, args ...any) ([]any, error) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(d):
+				return args, nil
+			}
+		}
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		return args[0].(int), nil
+	}
+
+	result, err := New[int](context.Background(), 5).
+		ThenWithTimeout(wait(time.Millisecond), time.Second).
+		Then(wait(time.Millisecond)).
+		Finally(f)
+
+	if err != nil || result != 5 {
+		t.Fatalf("unexpected result, got: %v, %v", result, err)
+	}
+
+	start := time.Now()
+
+	_, err = New[int](context.Background(), 5).
+		ThenWithTimeout(wait(time.Second), 5*time.Millisecond).
+		Finally(f)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded error, got: %v", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Fatalf("step deadline not applied, took: %v", d)
+	}
+}
+
+func TestChain_ThenWithTimeout_1(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	hang := func(ctx context.Context, args ...any) ([]any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	go func() {
+		<-time.After(5 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := New[int](ctx, 5).
+		ThenWithTimeout(hang, time.Second).
+		Finally(f)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected parent cancellation to abort the step, got: %v", err)
+	}
+}
+
+func TestChain_ThenWithTimeout_2(t *testing.T) {
+
+	step := func(ctx context.Context, args ...any) ([]any, error) {
+		if _, ok := ctx.Deadline(); ok {
+			return nil, errors.New("unexpected deadline")
+		}
+		return []any{args[0].(int) + 1}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	// A non-positive duration applies no deadline to the step
+	result, err := New[int](context.Background(), 1).
+		ThenWithTimeout(step, 0).
+		ThenWithTimeout(step, -time.Second).
+		Finally(f)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if result != 3 {
+		t.Fatalf("unexpected result, got: %d", result)
+	}
+}
@@ -0,0 +1,69 @@
+package chain
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Timing describes where the time taken by a step was spent, so that contention can be
+// distinguished from actual work
+type Timing struct {
+	// Attempts is the number of times the func was invoked, including retries
+	Attempts int
+	// WaitDuration is the time spent acquiring limiters or semaphores via WaitFor
+	WaitDuration time.Duration
+	// ExecDuration is the time spent in the func, excluding WaitDuration and any waits
+	// between retries
+	ExecDuration time.Duration
+}
+
+type waitKey struct{}
+
+// stepTimer accumulates the durations of a step, which may span concurrent invocations
+type stepTimer struct {
+	wait atomic.Int64
+	exec atomic.Int64
+}
+
+// WaitFor calls acquire, which should block until a limiter token, semaphore or similar
+// is obtained, attributing the time taken to the WaitDuration of the current step rather
+// than to its ExecDuration.  The error from acquire is returned unchanged.
+func WaitFor(ctx context.Context, acquire func(context.Context) error) error {
+	start := time.Now()
+	err := acquire(ctx)
+	if t, ok := ctx.Value(waitKey{}).(*stepTimer); ok {
+		t.wait.Add(int64(time.Since(start)))
+	}
+	return err
+}
+
+// timed returns the context and func to be used to invoke a step, together with a func to
+// be called with the outcome of the step that reports its Timing to Config.After.  If
+// there is no After hook then ctx and f are returned unchanged.
+func timed[R any](ctx context.Context, cfg Config, named any, f func(context.Context, ...any) (R, error)) (context.Context, func(context.Context, ...any) (R, error), func(int, error)) {
+	if cfg.After == nil {
+		return ctx, f, func(int, error) {}
+	}
+
+	t := &stepTimer{}
+
+	g := func(ctx context.Context, args ...any) (R, error) {
+		start := time.Now()
+		defer func() {
+			t.exec.Add(int64(time.Since(start)))
+		}()
+		return f(ctx, args...)
+	}
+
+	done := func(attempts int, err error) {
+		wait := time.Duration(t.wait.Load())
+		cfg.After(nameOf(named), Timing{
+			Attempts:     attempts,
+			WaitDuration: wait,
+			ExecDuration: max(time.Duration(t.exec.Load())-wait, 0),
+		}, err)
+	}
+
+	return context.WithValue(ctx, waitKey{}, t), g, done
+}
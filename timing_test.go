@@ -0,0 +1,83 @@
+package chain
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWaitFor(t *testing.T) {
+
+	sem := make(chan struct{}, 1)
+	sem <- struct{}{}
+
+	go func() {
+		<-time.After(20 * time.Millisecond)
+		<-sem
+	}()
+
+	f1 := func(ctx context.Context, args ...any) ([]any, error) {
+		err := WaitFor(ctx, func(ctx context.Context) error {
+			select {
+			case sem <- struct{}{}:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		defer func() { <-sem }()
+
+		<-time.After(5 * time.Millisecond)
+		return args, nil
+	}
+
+	f2 := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	timings := map[string]Timing{}
+	cfg := Config{
+		After: func(step string, timing Timing, err error) {
+			timings[step] = timing
+		},
+	}
+
+	_, err := NewWithConfig[int](context.Background(), cfg, 5).
+		ThenNamed("acquire", f1).
+		Finally(f2)
+
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if len(timings) != 2 {
+		t.Fatalf("expected After to be called for each step, got: %v", timings)
+	}
+
+	timing := timings["acquire"]
+	if timing.Attempts != 1 {
+		t.Fatalf("unexpected attempts, got: %d", timing.Attempts)
+	}
+	if timing.WaitDuration < 15*time.Millisecond {
+		t.Fatalf("expected wait to be recorded, got: %v", timing.WaitDuration)
+	}
+	if timing.ExecDuration < 5*time.Millisecond || timing.ExecDuration >= timing.WaitDuration {
+		t.Fatalf("expected exec to exclude wait, got: %+v", timing)
+	}
+}
+
+func TestWaitFor_1(t *testing.T) {
+
+	// WaitFor is usable outside of a timed step
+	called := false
+	err := WaitFor(context.Background(), func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+
+	if err != nil || !called {
+		t.Fatalf("expected acquire to be called, got: %v, %v", called, err)
+	}
+}
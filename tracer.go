@@ -0,0 +1,19 @@
+package chain
+
+import "context"
+
+// StepTracer is called as each step starts, with the context of the chain and the name
+// and zero-based index of the step.  It returns the context to be passed to the step's func,
+// which may carry a span, and the func to be called with the number of attempts made and
+// the error, if any, once the step ends.  This allows tracing to be integrated without the
+// chain package depending upon a tracing implementation; see the otelchain package.
+type StepTracer func(ctx context.Context, step string, index int) (context.Context, func(attempts int, err error))
+
+// trace starts the tracing of the named step if the chain has a StepTracer, returning the
+// context for the step and the func to be called once it ends
+func (c Chain[T]) trace(ctx context.Context, named any) (context.Context, func(int, error)) {
+	if c.cfg.Tracer == nil {
+		return ctx, func(int, error) {}
+	}
+	return c.cfg.Tracer(ctx, nameOf(named), c.step)
+}
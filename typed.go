@@ -0,0 +1,49 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Map adapts a func of a single typed arg and result into a Func, so that the func need not
+// assert the type of its arg.  The Func fails with ErrArgCount unless given exactly one
+// arg, and with ErrArgTypeMismatch if that arg is not of type In.  As errors are attributed
+// to the adapter, ThenNamed may be used to attribute them to a meaningful name.
+func Map[In, Out any](f func(context.Context, In) (Out, error)) Func {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, args ...any) ([]any, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("expected %d args, got %d: %w", 1, len(args), ErrArgCount)
+		}
+		in, err := Arg[In](args, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		out, err := f(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		return []any{out}, nil
+	}
+}
+
+// Arg returns the arg at index as type T, avoiding the panic of a failed type assertion.
+// ErrArgTypeMismatch is raised, identifying the index and the expected and actual types,
+// if the arg is not of type T, and ErrArgCount if there is no arg at index.
+func Arg[T any](args []any, index int) (T, error) {
+	var zero T
+	if index < 0 || index >= len(args) {
+		return zero, fmt.Errorf("no arg %d in %d args: %w", index, len(args), ErrArgCount)
+	}
+
+	t, ok := args[index].(T)
+	if !ok {
+		return zero, fmt.Errorf("arg %d is %T, not %v: %w", index, args[index], reflect.TypeFor[T](), ErrArgTypeMismatch)
+	}
+	return t, nil
+}
@@ -0,0 +1,106 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func ExampleMap() {
+
+	parse := func(ctx context.Context, s string) (int, error) {
+		return strconv.Atoi(s)
+	}
+
+	square := func(ctx context.Context, x int) (int, error) {
+		return x * x, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	result, _ := New[int](context.Background(), "7").
+		Then(Map(parse)).
+		Then(Map(square)).
+		Finally(f)
+
+	fmt.Println("Result:", result)
+	// Output: Result: 49
+}
+
+func TestMap(t *testing.T) {
+
+	square := func(ctx context.Context, x int) (int, error) {
+		return x * x, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	_, err := New[int](context.Background(), "7").
+		Then(Map(square)).
+		Finally(f)
+
+	if !errors.Is(err, ErrArgTypeMismatch) {
+		t.Fatalf("expected type mismatch error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "arg 0 is string, not int") {
+		t.Fatalf("expected descriptive error, got: %v", err)
+	}
+
+	_, err = New[int](context.Background(), 1, 2).
+		Then(Map(square)).
+		Finally(f)
+
+	if !errors.Is(err, ErrArgCount) {
+		t.Fatalf("expected arg count error, got: %v", err)
+	}
+
+	errFailed := errors.New("failed")
+	fail := func(ctx context.Context, x int) (int, error) {
+		return 0, errFailed
+	}
+
+	_, err = New[int](context.Background(), 1).
+		Then(Map(fail)).
+		Finally(f)
+
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("expected underlying error, got: %v", err)
+	}
+
+	if Map[int, int](nil) != nil {
+		t.Fatal("expected nil Func for nil func")
+	}
+}
+
+func TestArg(t *testing.T) {
+
+	args := []any{1, "two", nil}
+
+	if x, err := Arg[int](args, 0); err != nil || x != 1 {
+		t.Fatalf("unexpected result, got: %v, %v", x, err)
+	}
+	if s, err := Arg[fmt.Stringer](args, 2); err == nil {
+		t.Fatalf("expected nil arg not to satisfy interface, got: %v", s)
+	}
+
+	_, err := Arg[int](args, 1)
+	if !errors.Is(err, ErrArgTypeMismatch) {
+		t.Fatalf("expected type mismatch error, got: %v", err)
+	}
+	if err.Error() != "arg 1 is string, not int: arg type mismatch" {
+		t.Fatalf("expected index and types in error, got: %v", err)
+	}
+
+	for _, index := range []int{-1, 3} {
+		if _, err := Arg[int](args, index); !errors.Is(err, ErrArgCount) {
+			t.Fatalf("expected arg count error for index %d, got: %v", index, err)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrValidationFailed is raised when a rule passed to Validate fails
+var ErrValidationFailed = errors.New("validation failed")
+
+// Validate adds a step that checks the current args against each of the rules in turn,
+// forwarding the args unchanged if all pass.  The first rule to fail fails the chain, with
+// an error wrapping both ErrValidationFailed and the rule's error.  As rules are expected
+// to be deterministic, the chain's retry policy does not apply.
+func (c Chain[T]) Validate(rules ...func(args ...any) error) Chain[T] {
+	return c.queue("validate", check(ErrNilThenFunc, rules...), func(c Chain[T]) Chain[T] {
+		if c.err != nil {
+			return c
+		}
+		if slices.ContainsFunc(rules, func(rule func(args ...any) error) bool { return rule == nil }) {
+			return c.fail(ErrNilThenFunc)
+		}
+
+		validate := func(ctx context.Context, args ...any) ([]any, error) {
+			for i, rule := range rules {
+				if err := rule(args...); err != nil {
+					return nil, fmt.Errorf("rule %d, %w: %w", i, ErrValidationFailed, err)
+				}
+			}
+			return args, nil
+		}
+
+		return c.thenWithRetry(validate, "validate", Retry{})
+	})
+}
@@ -0,0 +1,67 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChain_Validate(t *testing.T) {
+
+	errEmpty := errors.New("no args")
+	errNegative := errors.New("negative")
+
+	notEmpty := func(args ...any) error {
+		if len(args) == 0 {
+			return errEmpty
+		}
+		return nil
+	}
+
+	var checked bool
+	positive := func(args ...any) error {
+		checked = true
+		if args[0].(int) < 0 {
+			return errNegative
+		}
+		return nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	result, err := New[int](context.Background(), 5).
+		Validate(notEmpty, positive).
+		Finally(f)
+
+	if err != nil || result != 5 {
+		t.Fatalf("expected args to pass through, got: %v, %v", result, err)
+	}
+
+	_, err = NewWithRetries[int](context.Background(), Retry{NumRetries: 3, BaseWait: time.Second}, -5).
+		Validate(notEmpty, positive).
+		Finally(f)
+
+	if !errors.Is(err, ErrValidationFailed) || !errors.Is(err, errNegative) {
+		t.Fatalf("expected validation error, got: %v", err)
+	}
+
+	checked = false
+	_, err = New[int](context.Background()).
+		Validate(notEmpty, positive).
+		Finally(f)
+
+	if !errors.Is(err, errEmpty) || checked {
+		t.Fatalf("expected first failing rule to abort, got: %v", err)
+	}
+
+	_, err = New[int](context.Background()).
+		Validate(nil).
+		Finally(f)
+
+	if !errors.Is(err, ErrNilThenFunc) {
+		t.Fatalf("expected nil func error, got: %v", err)
+	}
+}
@@ -0,0 +1,21 @@
+package chain
+
+import "context"
+
+// NewVoid starts a new pipeline that has no meaningful output, to be ended by FinallyVoid
+func NewVoid(ctx context.Context, args ...any) Chain[struct{}] {
+	return New[struct{}](ctx, args...)
+}
+
+// FinallyVoid ends the pipeline as Finally, for pipelines whose final func is invoked only
+// for its side effects, returning just the error of the chain
+func (c Chain[T]) FinallyVoid(f func(context.Context, ...any) error) error {
+	var g FinalFunc[T]
+	if f != nil {
+		g = func(ctx context.Context, args ...any) (T, error) {
+			return c.t, f(ctx, args...)
+		}
+	}
+	_, err := c.finally(g, f)
+	return err
+}
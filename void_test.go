@@ -0,0 +1,57 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChain_FinallyVoid(t *testing.T) {
+
+	var sent []any
+	send := func(ctx context.Context, args ...any) error {
+		sent = append(sent, args...)
+		return nil
+	}
+
+	if err := NewVoid(context.Background(), "event").FinallyVoid(send); err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	if len(sent) != 1 || sent[0] != "event" {
+		t.Fatalf("expected final func to be invoked, got: %v", sent)
+	}
+
+	errFailed := errors.New("failed")
+	var attempts int
+	fail := func(ctx context.Context, args ...any) error {
+		attempts++
+		return errFailed
+	}
+
+	err := NewWithRetries[int](context.Background(), Retry{NumRetries: 2, BaseWait: time.Millisecond}).
+		FinallyVoid(fail)
+
+	if !errors.Is(err, errFailed) || attempts != 3 {
+		t.Fatalf("expected retried error, got: %v after %d attempts", err, attempts)
+	}
+
+	boom := func(ctx context.Context, args ...any) error {
+		panic("Void Boom!")
+	}
+
+	if err := NewVoid(context.Background()).FinallyVoid(boom); !errors.Is(err, ErrUnhandledPanic) {
+		t.Fatalf("expected caught panic error, got: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := NewVoid(ctx).FinallyVoid(send); !errors.Is(err, ErrContextDone) {
+		t.Fatalf("expected context done error, got: %v", err)
+	}
+
+	if err := NewVoid(context.Background()).FinallyVoid(nil); !errors.Is(err, ErrNilFinalFunc) {
+		t.Fatalf("expected nil final func error, got: %v", err)
+	}
+}
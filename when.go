@@ -0,0 +1,34 @@
+package chain
+
+import (
+	"cmp"
+	"context"
+)
+
+// When adds a transformation step that invokes f only if pred holds for the current args,
+// otherwise forwarding them unchanged.  pred is evaluated after the context is checked, as
+// part of the step.
+func (c Chain[T]) When(pred func(args ...any) bool, f Func) Chain[T] {
+	return c.when(pred, true, f)
+}
+
+// Unless adds a transformation step that invokes f only if pred does not hold for the
+// current args, otherwise forwarding them unchanged
+func (c Chain[T]) Unless(pred func(args ...any) bool, f Func) Chain[T] {
+	return c.when(pred, false, f)
+}
+
+func (c Chain[T]) when(pred func(args ...any) bool, want bool, f Func) Chain[T] {
+	return c.queue(f, cmp.Or(check(ErrNilThenFunc, pred), check(ErrNilThenFunc, f)), func(c Chain[T]) Chain[T] {
+		var g Func
+		if pred != nil && f != nil {
+			g = func(ctx context.Context, args ...any) ([]any, error) {
+				if pred(args...) != want {
+					return args, nil
+				}
+				return f(ctx, args...)
+			}
+		}
+		return c.then(g, f)
+	})
+}
@@ -0,0 +1,105 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleChain_When() {
+
+	negative := func(args ...any) bool {
+		return args[0].(int) < 0
+	}
+
+	negate := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{-args[0].(int)}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return args[0].(int), nil
+	}
+
+	for _, input := range []int{-5, 5} {
+		result, _ := New[int](context.Background(), input).
+			When(negative, negate).
+			Finally(f)
+
+		fmt.Println("Result:", result)
+	}
+	// Output:
+	// Result: 5
+	// Result: 5
+}
+
+func TestChain_When(t *testing.T) {
+
+	always := func(args ...any) bool { return true }
+	never := func(args ...any) bool { return false }
+
+	double := func(ctx context.Context, args ...any) ([]any, error) {
+		return []any{args[0].(int) * 2, "doubled"}, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (string, error) {
+		return fmt.Sprintf("%v", args), nil
+	}
+
+	tests := []struct {
+		name  string
+		apply func(Chain[string]) Chain[string]
+		want  string
+	}{
+		{"when taken", func(c Chain[string]) Chain[string] { return c.When(always, double) }, "[10 doubled]"},
+		{"when skipped", func(c Chain[string]) Chain[string] { return c.When(never, double) }, "[5 x]"},
+		{"unless taken", func(c Chain[string]) Chain[string] { return c.Unless(never, double) }, "[10 doubled]"},
+		{"unless skipped", func(c Chain[string]) Chain[string] { return c.Unless(always, double) }, "[5 x]"},
+	}
+
+	for _, test := range tests {
+		result, err := test.apply(New[string](context.Background(), 5, "x")).Finally(f)
+		if err != nil {
+			t.Fatalf("%s: unexpected error, got: %v", test.name, err)
+		}
+		if result != test.want {
+			t.Fatalf("%s: unexpected result.  wanted: %s, got: %s", test.name, test.want, result)
+		}
+	}
+}
+
+func TestChain_When_1(t *testing.T) {
+
+	var evaluated bool
+	pred := func(args ...any) bool {
+		evaluated = true
+		return true
+	}
+
+	pass := func(ctx context.Context, args ...any) ([]any, error) {
+		return args, nil
+	}
+
+	f := func(ctx context.Context, args ...any) (int, error) {
+		return 0, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := New[int](ctx).
+		When(pred, pass).
+		Finally(f)
+
+	if !errors.Is(err, ErrContextDone) || evaluated {
+		t.Fatalf("expected context to be checked before the predicate, got: %v", err)
+	}
+
+	_, err = New[int](context.Background()).
+		Unless(nil, pass).
+		Finally(f)
+
+	if !errors.Is(err, ErrNilThenFunc) {
+		t.Fatalf("expected nil func error, got: %v", err)
+	}
+}